@@ -5,6 +5,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 	"os"
+	"regexp"
 )
 
 type AuthMethod int
@@ -28,6 +29,68 @@ type SchemaRegistryConfig struct {
 	Url      string `yaml:"url"`
 	Username string `yaml:"username"`
 	Password string `yaml:"password"`
+	// ValidateJsonSchema validates JSON payloads against their registered schema when consuming,
+	// payloads not matching their schema are shown as an error.
+	ValidateJsonSchema bool `yaml:"validate-json-schema,omitempty"`
+}
+
+// Format is the serialization format of record keys or values.
+type Format string
+
+const (
+	// DefaultFormat decodes data written with a schema from the schema registry,
+	// recognized by its magic byte, and treats other data as a string.
+	DefaultFormat  Format = ""
+	StringFormat   Format = "string"
+	JsonFormat     Format = "json"
+	AvroFormat     Format = "avro"
+	ProtobufFormat Format = "protobuf"
+	HexFormat      Format = "hex"
+	Base64Format   Format = "base64"
+	// LongFormat is a big-endian 64-bit integer
+	LongFormat Format = "long"
+)
+
+var Formats = []Format{
+	StringFormat,
+	JsonFormat,
+	AvroFormat,
+	ProtobufFormat,
+	HexFormat,
+	Base64Format,
+	LongFormat,
+}
+
+// TopicFormat selects the format of the keys and values of a topic,
+// or of all topics matching a regular expression.
+type TopicFormat struct {
+	Topic string `yaml:"topic,omitempty"`
+	// TopicPattern is a regular expression that has to match the whole topic name
+	TopicPattern string `yaml:"topic-pattern,omitempty"`
+	Key          Format `yaml:"key,omitempty"`
+	Value        Format `yaml:"value,omitempty"`
+	// pattern is TopicPattern compiled when the config is loaded
+	pattern *regexp.Regexp
+}
+
+func (f *TopicFormat) appliesTo(topic string) bool {
+	if f.Topic != "" {
+		return f.Topic == topic
+	}
+	return f.pattern != nil && f.pattern.MatchString(topic)
+}
+
+// compile compiles the TopicPattern so it is only done once.
+func (f *TopicFormat) compile() error {
+	if f.TopicPattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile("^(?:" + f.TopicPattern + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid topic-pattern %q: %w", f.TopicPattern, err)
+	}
+	f.pattern = pattern
+	return nil
 }
 
 type Cluster struct {
@@ -37,12 +100,34 @@ type Cluster struct {
 	BootstrapServers []string              `yaml:"servers"`
 	SASLConfig       *SASLConfig           `yaml:"sasl"`
 	SchemaRegistry   *SchemaRegistryConfig `yaml:"schema-registry"`
+	TopicFormats     []TopicFormat         `yaml:"topic-formats,omitempty"`
 }
 
 func (c *Cluster) HasSchemaRegistry() bool {
 	return c.SchemaRegistry != nil
 }
 
+// ValidatesJsonSchema tells whether JSON payloads are validated against their registered schema.
+func (c *Cluster) ValidatesJsonSchema() bool {
+	return c.HasSchemaRegistry() && c.SchemaRegistry.ValidateJsonSchema
+}
+
+// FormatsOf returns the key and value format of the topic according to the first
+// TopicFormat applying to it, DefaultFormat when there is none.
+func FormatsOf(topicFormats []TopicFormat, topic string) (Format, Format) {
+	for _, topicFormat := range topicFormats {
+		if topicFormat.appliesTo(topic) {
+			return topicFormat.Key, topicFormat.Value
+		}
+	}
+	return DefaultFormat, DefaultFormat
+}
+
+// FormatsOf returns the key and value format of the topic, see FormatsOf.
+func (c *Cluster) FormatsOf(topic string) (Format, Format) {
+	return FormatsOf(c.TopicFormats, topic)
+}
+
 type Config struct {
 	Clusters []Cluster `yaml:"clusters"`
 	ConfigIO IO        `yaml:"-"`
@@ -52,6 +137,18 @@ func (c *Config) HasClusters() bool {
 	return len(c.Clusters) > 0
 }
 
+// compileTopicPatterns compiles the topic patterns of all clusters, rejecting invalid ones.
+func (c *Config) compileTopicPatterns() error {
+	for i := range c.Clusters {
+		for j := range c.Clusters[i].TopicFormats {
+			if err := c.Clusters[i].TopicFormats[j].compile(); err != nil {
+				return fmt.Errorf("cluster %s: %w", c.Clusters[i].Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 type SchemaRegistryDetails struct {
 	Url      string
 	Username string
@@ -103,6 +200,11 @@ func (c *Config) RegisterCluster(details RegistrationDetails) tea.Msg {
 		if c.Clusters[i].Name == details.Name {
 			isActive := c.Clusters[i].Active
 			cluster.Active = isActive
+			// topic formats and schema validation are not part of the registration details
+			cluster.TopicFormats = c.Clusters[i].TopicFormats
+			if cluster.SchemaRegistry != nil && c.Clusters[i].SchemaRegistry != nil {
+				cluster.SchemaRegistry.ValidateJsonSchema = c.Clusters[i].SchemaRegistry.ValidateJsonSchema
+			}
 			c.Clusters[i] = cluster
 			if details.NewName != nil {
 				c.Clusters[i].Name = *details.NewName
@@ -231,6 +333,9 @@ type LoadedMsg struct {
 
 func New(configIO IO) *Config {
 	config, err := configIO.read()
+	if err == nil {
+		err = config.compileTopicPatterns()
+	}
 	if err != nil {
 		fmt.Println("Error reading config file:", err)
 		os.Exit(-1)
@@ -376,4 +376,111 @@ func TestConfig(t *testing.T) {
 		// then
 		assert.Nil(t, cluster)
 	})
+
+	t.Run("Registering an existing cluster keeps its topic formats", func(t *testing.T) {
+		// given
+		config := New(&InMemoryConfigIO{})
+		config.RegisterCluster(RegistrationDetails{
+			Name:       "prd",
+			Color:      "#880808",
+			Host:       "localhost:9092",
+			AuthMethod: NoneAuthMethod,
+		})
+		config.Clusters[0].TopicFormats = []TopicFormat{{Topic: "orders", Value: ProtobufFormat}}
+
+		// when
+		config.RegisterCluster(RegistrationDetails{
+			Name:       "prd",
+			Color:      "#880801",
+			Host:       "localhost:9093",
+			AuthMethod: NoneAuthMethod,
+		})
+
+		// then
+		assert.Equal(t, []TopicFormat{{Topic: "orders", Value: ProtobufFormat}}, config.Clusters[0].TopicFormats)
+	})
+
+	t.Run("Registering an existing cluster keeps its JSON schema validation", func(t *testing.T) {
+		// given
+		config := New(&InMemoryConfigIO{})
+		details := RegistrationDetails{
+			Name:           "prd",
+			Color:          "#880808",
+			Host:           "localhost:9092",
+			AuthMethod:     NoneAuthMethod,
+			SchemaRegistry: &SchemaRegistryDetails{Url: "http://localhost:8081"},
+		}
+		config.RegisterCluster(details)
+		config.Clusters[0].SchemaRegistry.ValidateJsonSchema = true
+
+		// when
+		config.RegisterCluster(details)
+
+		// then
+		assert.True(t, config.Clusters[0].ValidatesJsonSchema())
+	})
+
+	t.Run("Formats of a topic", func(t *testing.T) {
+		config := New(NewInMemoryConfigIO(&Config{
+			Clusters: []Cluster{{
+				Name: "prd",
+				TopicFormats: []TopicFormat{
+					{Topic: "orders", Key: StringFormat, Value: ProtobufFormat},
+					{TopicPattern: "metrics\\..*", Key: LongFormat, Value: JsonFormat},
+					{TopicPattern: ".*-raw", Value: HexFormat},
+				},
+			}},
+		}))
+		cluster := config.Clusters[0]
+
+		t.Run("by exact name", func(t *testing.T) {
+			key, value := cluster.FormatsOf("orders")
+
+			assert.Equal(t, StringFormat, key)
+			assert.Equal(t, ProtobufFormat, value)
+		})
+
+		t.Run("by pattern", func(t *testing.T) {
+			key, value := cluster.FormatsOf("metrics.cpu")
+
+			assert.Equal(t, LongFormat, key)
+			assert.Equal(t, JsonFormat, value)
+		})
+
+		t.Run("pattern has to match the whole topic name", func(t *testing.T) {
+			key, value := cluster.FormatsOf("app.metrics.cpu")
+
+			assert.Equal(t, DefaultFormat, key)
+			assert.Equal(t, DefaultFormat, value)
+		})
+
+		t.Run("by second pattern", func(t *testing.T) {
+			key, value := cluster.FormatsOf("orders-raw")
+
+			assert.Equal(t, DefaultFormat, key)
+			assert.Equal(t, HexFormat, value)
+		})
+
+		t.Run("without rule", func(t *testing.T) {
+			key, value := cluster.FormatsOf("payments")
+
+			assert.Equal(t, DefaultFormat, key)
+			assert.Equal(t, DefaultFormat, value)
+		})
+	})
+}
+
+func TestCompileTopicPatterns(t *testing.T) {
+	t.Run("Reject invalid patterns", func(t *testing.T) {
+		config := &Config{
+			Clusters: []Cluster{{
+				Name:         "prd",
+				TopicFormats: []TopicFormat{{TopicPattern: "invalid(", Value: HexFormat}},
+			}},
+		}
+
+		err := config.compileTopicPatterns()
+
+		assert.ErrorContains(t, err, `cluster prd: invalid topic-pattern "invalid("`)
+	})
 }
@@ -0,0 +1,72 @@
+package kadmin
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"sort"
+)
+
+type BrokerLister interface {
+	ListBrokers() tea.Msg
+}
+
+type Broker struct {
+	ID      int32
+	Address string
+	Rack    string
+}
+
+type BrokerListingStartedMsg struct {
+	Err     chan error
+	Brokers chan []Broker
+}
+
+type BrokersListedMsg struct {
+	Brokers []Broker
+}
+
+type BrokerListingErrorMsg struct {
+	Err error
+}
+
+func (m *BrokerListingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case brokers := <-m.Brokers:
+		return BrokersListedMsg{brokers}
+	case err := <-m.Err:
+		return BrokerListingErrorMsg{err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) ListBrokers() tea.Msg {
+	errChan := make(chan error)
+	brokersChan := make(chan []Broker)
+
+	go ka.doListBrokers(brokersChan, errChan)
+
+	return BrokerListingStartedMsg{
+		errChan,
+		brokersChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doListBrokers(brokersChan chan []Broker, errChan chan error) {
+	maybeIntroduceLatency()
+	brokers, _, err := ka.admin.DescribeCluster()
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	var result []Broker
+	for _, b := range brokers {
+		result = append(result, Broker{
+			ID:      b.ID(),
+			Address: b.Addr(),
+			Rack:    b.Rack(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	brokersChan <- result
+}
@@ -27,8 +27,8 @@ func TestCGroupDeleter(t *testing.T) {
 		// publish some data on the topic
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
-				Value:     "value",
+				Key:       []byte("key"),
+				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
 			})
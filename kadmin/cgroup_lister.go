@@ -1,14 +1,30 @@
 package kadmin
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"maps"
+	"slices"
+)
 
 type CGroupLister interface {
 	ListCGroups() tea.Msg
 }
 
 type ConsumerGroup struct {
-	Name    string
-	Members []GroupMember
+	Name string
+	// State is the state reported by the coordinator, e.g. Stable, Empty, PreparingRebalance or Dead.
+	State string
+	// ProtocolType is the kind of group, consumer for regular consumer groups.
+	ProtocolType string
+	// Protocol is the partition assignment strategy the members agreed on, e.g. range.
+	Protocol string
+	// Coordinator is the broker managing the group, nil when it could not be found.
+	Coordinator *Broker
+	Members     []GroupMember
+	// TotalLag is the lag summed over all partitions the group committed offsets for, or UnknownLag.
+	TotalLag int64
 }
 
 type ConsumerGroupListingStartedMsg struct {
@@ -52,7 +68,7 @@ func (ka *SaramaKafkaAdmin) doListConsumerGroups(groupsChan chan []*ConsumerGrou
 		var groupByName = make(map[string]*ConsumerGroup)
 
 		for name, _ := range listGroupResponse {
-			consumerGroup := ConsumerGroup{Name: name}
+			consumerGroup := ConsumerGroup{Name: name, TotalLag: UnknownLag}
 			consumerGroups = append(consumerGroups, &consumerGroup)
 			groupByName[name] = &consumerGroup
 			groupNames = append(groupNames, name)
@@ -66,16 +82,70 @@ func (ka *SaramaKafkaAdmin) doListConsumerGroups(groupsChan chan []*ConsumerGrou
 
 		for _, groupDescription := range describeConsumerGroupResponse {
 			group := groupByName[groupDescription.GroupId]
+			group.State = groupDescription.State
+			group.ProtocolType = groupDescription.ProtocolType
+			group.Protocol = groupDescription.Protocol
 			var groupMembers []GroupMember
 			for _, m := range groupDescription.Members {
 				member := GroupMember{}
 				member.MemberId = m.MemberId
 				member.ClientId = m.ClientId
 				member.ClientHost = m.ClientHost
+				member.Assignment = memberAssignment(group.Name, m)
 				groupMembers = append(groupMembers, member)
 			}
 			group.Members = groupMembers
 		}
+
+		ka.determineCoordinators(consumerGroups)
+		ka.determineTotalLags(groupByName)
+
 		groupsChan <- consumerGroups
 	}
 }
+
+// memberAssignment decodes the partitions assigned to a member, which is only possible for consumer groups.
+func memberAssignment(group string, m *sarama.GroupMemberDescription) map[string][]int32 {
+	assignment, err := m.GetMemberAssignment()
+	if err != nil {
+		log.Warn("Unable to decode member assignment", "group", group, "member", m.MemberId, "err", err)
+		return nil
+	}
+	if assignment == nil {
+		return nil
+	}
+	return assignment.Topics
+}
+
+// determineCoordinators looks up the brokers managing the groups concurrently.
+func (ka *SaramaKafkaAdmin) determineCoordinators(groups []*ConsumerGroup) {
+	forEachConcurrently(groups, func(_ int, group *ConsumerGroup) {
+		group.Coordinator = ka.coordinator(group.Name)
+	})
+}
+
+// coordinator looks up the broker managing the group, nil when it cannot be found.
+func (ka *SaramaKafkaAdmin) coordinator(group string) *Broker {
+	b, err := ka.client.Coordinator(group)
+	if err != nil {
+		log.Warn("Unable to find coordinator", "group", group, "err", err)
+		return nil
+	}
+	return &Broker{
+		ID:      b.ID(),
+		Address: b.Addr(),
+		Rack:    b.Rack(),
+	}
+}
+
+// determineTotalLags fetches the committed offsets of the groups concurrently,
+// then requests the high watermarks of the partitions of all groups at once.
+func (ka *SaramaKafkaAdmin) determineTotalLags(groupByName map[string]*ConsumerGroup) {
+	offsetsByGroup := ka.committedOffsetsOfGroups(slices.Collect(maps.Keys(groupByName)))
+
+	ka.determineLags(slices.Collect(maps.Values(offsetsByGroup))...)
+
+	for name, offsets := range offsetsByGroup {
+		groupByName[name].TotalLag = TotalLag(offsets)
+	}
+}
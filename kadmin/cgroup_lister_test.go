@@ -28,8 +28,8 @@ func TestConsumerGroups(t *testing.T) {
 
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
-				Value:     "value",
+				Key:       []byte("key"),
+				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
 			})
@@ -63,6 +63,12 @@ func TestConsumerGroups(t *testing.T) {
 					assert.NotEmpty(t, group.Members[0].MemberId)
 					assert.NotEmpty(t, group.Members[0].ClientId)
 					assert.NotEmpty(t, group.Members[0].ClientHost)
+					assert.Equal(t, int64(1), group.TotalLag)
+					assert.Equal(t, "Stable", group.State)
+					assert.Equal(t, "consumer", group.ProtocolType)
+					assert.Equal(t, "range", group.Protocol)
+					assert.NotNil(t, group.Coordinator)
+					assert.Equal(t, map[string][]int32{topic: {0}}, group.Members[0].Assignment)
 					expectedGroups[group.Name] = true
 				}
 			}
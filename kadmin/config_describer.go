@@ -0,0 +1,99 @@
+package kadmin
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/IBM/sarama"
+	kgo "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"time"
+)
+
+// configDescriber describes the configs of a topic, including their documentation.
+type configDescriber interface {
+	DescribeTopicConfigs(topic string) (map[string]TopicConfig, error)
+	Close()
+}
+
+// kafkaGoConfigDescriber describes configs with kafka-go, as sarama does not support
+// DescribeConfigs v3 which includes the documentation of configs.
+type kafkaGoConfigDescriber struct {
+	client *kgo.Client
+}
+
+func (d *kafkaGoConfigDescriber) DescribeTopicConfigs(topic string) (map[string]TopicConfig, error) {
+	// synonyms are needed to know the defaults
+	resp, err := d.client.DescribeConfigs(context.Background(), &kgo.DescribeConfigsRequest{
+		Resources: []kgo.DescribeConfigRequestResource{{
+			ResourceType: kgo.ResourceTypeTopic,
+			ResourceName: topic,
+		}},
+		IncludeSynonyms:      true,
+		IncludeDocumentation: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	configs := make(map[string]TopicConfig)
+	for _, r := range resp.Resources {
+		if r.Error != nil {
+			return nil, fmt.Errorf("unable to describe configs of %s: %w", topic, r.Error)
+		}
+		for _, e := range r.ConfigEntries {
+			configs[e.ConfigName] = toTopicConfig(e)
+		}
+	}
+	return configs, nil
+}
+
+func (d *kafkaGoConfigDescriber) Close() {
+	if t, ok := d.client.Transport.(*kgo.Transport); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// newKafkaGoConfigDescriber connects with the same TLS and SASL settings as the sarama client.
+func newKafkaGoConfigDescriber(cd ConnectionDetails, timeout time.Duration) *kafkaGoConfigDescriber {
+	transport := &kgo.Transport{}
+	if cd.SASLConfig != nil {
+		transport.TLS = &tls.Config{}
+		transport.SASL = plain.Mechanism{
+			Username: cd.SASLConfig.Username,
+			Password: cd.SASLConfig.Password,
+		}
+	}
+	return &kafkaGoConfigDescriber{
+		client: &kgo.Client{
+			Addr:      kgo.TCP(cd.BootstrapServers...),
+			Timeout:   timeout,
+			Transport: transport,
+		},
+	}
+}
+
+func toTopicConfig(e kgo.DescribeConfigResponseConfigEntry) TopicConfig {
+	config := TopicConfig{
+		Name:      e.ConfigName,
+		Value:     e.ConfigValue,
+		Source:    toConfigSource(sarama.ConfigSource(e.ConfigSource)),
+		ReadOnly:  e.ReadOnly,
+		Sensitive: e.IsSensitive,
+		Doc:       e.ConfigDocumentation,
+	}
+	for _, s := range e.ConfigSynonyms {
+		synonym := ConfigSynonym{
+			Name:   s.ConfigName,
+			Value:  s.ConfigValue,
+			Source: toConfigSource(sarama.ConfigSource(s.ConfigSource)),
+		}
+		config.Synonyms = append(config.Synonyms, synonym)
+		if config.Default == "" && synonym.Source != TopicConfigSource {
+			config.Default = synonym.Value
+		}
+	}
+	if config.Default == "" && !config.IsOverride() {
+		config.Default = config.Value
+	}
+	return config
+}
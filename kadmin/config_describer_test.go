@@ -0,0 +1,57 @@
+package kadmin
+
+import (
+	"github.com/IBM/sarama"
+	kgo "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCloseConfigDescriber(t *testing.T) {
+	t.Run("Close idle connections of the transport", func(t *testing.T) {
+		d := newKafkaGoConfigDescriber(ConnectionDetails{BootstrapServers: []string{"localhost:9092"}}, 0)
+
+		assert.NotPanics(t, d.Close)
+	})
+
+	t.Run("Ignore a client without transport", func(t *testing.T) {
+		d := &kafkaGoConfigDescriber{client: &kgo.Client{}}
+
+		assert.NotPanics(t, d.Close)
+	})
+}
+
+func TestToTopicConfig(t *testing.T) {
+	t.Run("Default of an override is the first synonym not set on the topic", func(t *testing.T) {
+		config := toTopicConfig(kgo.DescribeConfigResponseConfigEntry{
+			ConfigName:   "retention.ms",
+			ConfigValue:  "3600000",
+			ConfigSource: int8(sarama.SourceTopic),
+			ConfigSynonyms: []kgo.DescribeConfigResponseConfigSynonym{
+				{ConfigName: "retention.ms", ConfigValue: "3600000", ConfigSource: int8(sarama.SourceTopic)},
+				{ConfigName: "log.retention.ms", ConfigValue: "86400000", ConfigSource: int8(sarama.SourceStaticBroker)},
+				{ConfigName: "log.retention.hours", ConfigValue: "168", ConfigSource: int8(sarama.SourceDefault)},
+			},
+		})
+
+		assert.True(t, config.IsOverride())
+		assert.Equal(t, "86400000", config.Default)
+		assert.Len(t, config.Synonyms, 3)
+	})
+
+	t.Run("Default of a config without synonyms is its value", func(t *testing.T) {
+		config := toTopicConfig(kgo.DescribeConfigResponseConfigEntry{
+			ConfigName:          "cleanup.policy",
+			ConfigValue:         "delete",
+			ConfigSource:        int8(sarama.SourceDefault),
+			ReadOnly:            true,
+			ConfigDocumentation: "The retention policy to use on log segments.",
+		})
+
+		assert.False(t, config.IsOverride())
+		assert.Equal(t, "delete", config.Default)
+		assert.Equal(t, DefaultConfigSource, config.Source)
+		assert.True(t, config.ReadOnly)
+		assert.Equal(t, "The retention policy to use on log segments.", config.Doc)
+	})
+}
@@ -9,13 +9,67 @@ type TopicConfigLister interface {
 	ListConfigs(topic string) tea.Msg
 }
 
+// ConfigSource tells where the value of a config comes from.
+type ConfigSource int
+
+const (
+	UnknownConfigSource ConfigSource = iota
+	TopicConfigSource
+	DynamicBrokerConfigSource
+	DynamicDefaultBrokerConfigSource
+	StaticBrokerConfigSource
+	DefaultConfigSource
+)
+
+func (s ConfigSource) String() string {
+	switch s {
+	case TopicConfigSource:
+		return "topic"
+	case DynamicBrokerConfigSource:
+		return "dynamic broker"
+	case DynamicDefaultBrokerConfigSource:
+		return "dynamic broker default"
+	case StaticBrokerConfigSource:
+		return "static broker"
+	case DefaultConfigSource:
+		return "default"
+	default:
+		return "unknown"
+	}
+}
+
+type ConfigSynonym struct {
+	Name   string
+	Value  string
+	Source ConfigSource
+}
+
+type TopicConfig struct {
+	Name  string
+	Value string
+	// Default is the value the config reverts to when the topic override is removed, empty when unknown.
+	Default   string
+	Source    ConfigSource
+	ReadOnly  bool
+	Sensitive bool
+	// Synonyms holds the configs the value is resolved from, in order of precedence.
+	Synonyms []ConfigSynonym
+	// Doc is the documentation of the config, empty when the broker does not provide it.
+	Doc string
+}
+
+// IsOverride returns true when the value is set on the topic itself.
+func (c TopicConfig) IsOverride() bool {
+	return c.Source == TopicConfigSource
+}
+
 type TopicConfigListingStartedMsg struct {
 	Err     chan error
-	Configs chan map[string]string
+	Configs chan map[string]TopicConfig
 }
 
 type TopicConfigsListedMsg struct {
-	Configs map[string]string
+	Configs map[string]TopicConfig
 }
 
 type TopicConfigListingErrorMsg struct {
@@ -33,7 +87,7 @@ func (m *TopicConfigListingStartedMsg) AwaitCompletion() tea.Msg {
 
 func (ka *SaramaKafkaAdmin) ListConfigs(topic string) tea.Msg {
 	errChan := make(chan error)
-	configsChan := make(chan map[string]string)
+	configsChan := make(chan map[string]TopicConfig)
 
 	go ka.doListConfigs(topic, configsChan, errChan)
 
@@ -43,19 +97,29 @@ func (ka *SaramaKafkaAdmin) ListConfigs(topic string) tea.Msg {
 	}
 }
 
-func (ka *SaramaKafkaAdmin) doListConfigs(topic string, configsChan chan map[string]string, errorChan chan error) {
+func (ka *SaramaKafkaAdmin) doListConfigs(topic string, configsChan chan map[string]TopicConfig, errorChan chan error) {
 	maybeIntroduceLatency()
-	configsResp, err := ka.admin.DescribeConfig(sarama.ConfigResource{
-		Type: TOPIC_RESOURCE_TYPE,
-		Name: topic,
-	})
+	configs, err := ka.configDescriber.DescribeTopicConfigs(topic)
 	if err != nil {
 		errorChan <- err
 		return
 	}
-	configs := make(map[string]string)
-	for _, e := range configsResp {
-		configs[e.Name] = e.Value
-	}
 	configsChan <- configs
 }
+
+func toConfigSource(s sarama.ConfigSource) ConfigSource {
+	switch s {
+	case sarama.SourceTopic:
+		return TopicConfigSource
+	case sarama.SourceDynamicBroker:
+		return DynamicBrokerConfigSource
+	case sarama.SourceDynamicDefaultBroker:
+		return DynamicDefaultBrokerConfigSource
+	case sarama.SourceStaticBroker:
+		return StaticBrokerConfigSource
+	case sarama.SourceDefault:
+		return DefaultConfigSource
+	default:
+		return UnknownConfigSource
+	}
+}
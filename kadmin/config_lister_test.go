@@ -1,6 +1,7 @@
 package kadmin
 
 import (
+	"errors"
 	kgo "github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -22,7 +23,7 @@ func TestListTopicConfigs(t *testing.T) {
 		msg := ka.ListConfigs(topic).(TopicConfigListingStartedMsg)
 
 		// then
-		var configs map[string]string
+		var configs map[string]TopicConfig
 		select {
 		case c := <-msg.Configs:
 			configs = c
@@ -30,9 +31,76 @@ func TestListTopicConfigs(t *testing.T) {
 			assert.Fail(t, "Failed to list configs", e)
 			return
 		}
-		assert.Equal(t, "delete", configs["cleanup.policy"])
+		assert.Equal(t, "delete", configs["cleanup.policy"].Value)
+		assert.Equal(t, DefaultConfigSource, configs["cleanup.policy"].Source)
+		assert.Equal(t, "delete", configs["cleanup.policy"].Default)
 
 		// clean up
 		ka.DeleteTopic(topic)
 	})
+
+	t.Run("List overrides with their defaults", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+				ConfigEntries: []kgo.ConfigEntry{
+					{ConfigName: "retention.ms", ConfigValue: "3600000"},
+				},
+			},
+		})
+
+		//when
+		configs := listConfigs(t, topic)
+
+		// then
+		retention := configs["retention.ms"]
+		assert.Equal(t, "3600000", retention.Value)
+		assert.True(t, retention.IsOverride())
+		assert.Equal(t, "604800000", retention.Default)
+		assert.False(t, retention.ReadOnly)
+		assert.NotEmpty(t, retention.Doc)
+		assert.Equal(t, ConfigSynonym{
+			Name:   "retention.ms",
+			Value:  "3600000",
+			Source: TopicConfigSource,
+		}, retention.Synonyms[0])
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+}
+
+type fakeConfigDescriber struct {
+	configs map[string]TopicConfig
+	err     error
+}
+
+func (f *fakeConfigDescriber) DescribeTopicConfigs(topic string) (map[string]TopicConfig, error) {
+	return f.configs, f.err
+}
+
+func (f *fakeConfigDescriber) Close() {}
+
+func TestListConfigsOfDescriber(t *testing.T) {
+	t.Run("List the described configs", func(t *testing.T) {
+		configs := map[string]TopicConfig{"cleanup.policy": {Name: "cleanup.policy", Value: "delete"}}
+		admin := &SaramaKafkaAdmin{configDescriber: &fakeConfigDescriber{configs: configs}}
+
+		msg := admin.ListConfigs("topic").(TopicConfigListingStartedMsg)
+
+		assert.Equal(t, TopicConfigsListedMsg{configs}, msg.AwaitCompletion())
+	})
+
+	t.Run("Fail when the configs cannot be described", func(t *testing.T) {
+		err := errors.New("unknown topic")
+		admin := &SaramaKafkaAdmin{configDescriber: &fakeConfigDescriber{err: err}}
+
+		msg := admin.ListConfigs("topic").(TopicConfigListingStartedMsg)
+
+		assert.Equal(t, TopicConfigListingErrorMsg{err}, msg.AwaitCompletion())
+	})
 }
@@ -1,17 +1,41 @@
 package kadmin
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 type ConfigUpdater interface {
 	UpdateConfig(t TopicConfigToUpdate) tea.Msg
+	// ValidateConfig performs a dry run of the update, returning the broker-side errors without applying it.
+	ValidateConfig(t TopicConfigToUpdate) tea.Msg
 }
 
+// ConfigOperation determines how an update changes the current value of a config.
+type ConfigOperation int
+
+const (
+	// SetConfig overrides the value.
+	SetConfig ConfigOperation = iota
+	// DeleteConfig removes the override, reverting the config to its default.
+	DeleteConfig
+	// AppendConfig adds the value to a list config.
+	AppendConfig
+	// SubtractConfig removes the value from a list config.
+	SubtractConfig
+)
+
 type TopicConfigUpdatedMsg struct{}
 
+type TopicConfigValidatedMsg struct {
+	Config TopicConfigToUpdate
+}
+
 type TopicConfigToUpdate struct {
-	Topic string
-	Key   string
-	Value string
+	Topic     string
+	Key       string
+	Value     string
+	Operation ConfigOperation
 }
 
 type UpdateTopicConfigErrorMsg struct {
@@ -19,14 +43,39 @@ type UpdateTopicConfigErrorMsg struct {
 }
 
 func (ka *SaramaKafkaAdmin) UpdateConfig(t TopicConfigToUpdate) tea.Msg {
-	err := ka.admin.AlterConfig(
-		TOPIC_RESOURCE_TYPE,
-		t.Topic,
-		map[string]*string{t.Key: &t.Value},
-		false,
-	)
+	err := ka.alterConfig(t, false)
 	if err != nil {
 		return KAdminErrorMsg{err}
 	}
 	return TopicConfigUpdatedMsg{}
 }
+
+func (ka *SaramaKafkaAdmin) ValidateConfig(t TopicConfigToUpdate) tea.Msg {
+	err := ka.alterConfig(t, true)
+	if err != nil {
+		return KAdminErrorMsg{err}
+	}
+	return TopicConfigValidatedMsg{t}
+}
+
+// alterConfig incrementally updates a single config, leaving the other overrides of the topic untouched.
+func (ka *SaramaKafkaAdmin) alterConfig(t TopicConfigToUpdate, validateOnly bool) error {
+	entry := sarama.IncrementalAlterConfigsEntry{Value: &t.Value}
+	switch t.Operation {
+	case SetConfig:
+		entry.Operation = sarama.IncrementalAlterConfigsOperationSet
+	case DeleteConfig:
+		entry.Operation = sarama.IncrementalAlterConfigsOperationDelete
+		entry.Value = nil
+	case AppendConfig:
+		entry.Operation = sarama.IncrementalAlterConfigsOperationAppend
+	case SubtractConfig:
+		entry.Operation = sarama.IncrementalAlterConfigsOperationSubtract
+	}
+	return ka.admin.IncrementalAlterConfig(
+		TOPIC_RESOURCE_TYPE,
+		t.Topic,
+		map[string]sarama.IncrementalAlterConfigsEntry{t.Key: entry},
+		validateOnly,
+	)
+}
@@ -20,16 +20,16 @@ func TestUpdateTopicConfig(t *testing.T) {
 
 		// when
 		ka.UpdateConfig(TopicConfigToUpdate{
-			topic,
-			"delete.retention.ms",
-			"172800000"},
-		)
+			Topic: topic,
+			Key:   "delete.retention.ms",
+			Value: "172800000",
+		})
 
 		// then
 
 		// then
 		msg := ka.ListConfigs(topic).(TopicConfigListingStartedMsg)
-		var configs map[string]string
+		var configs map[string]TopicConfig
 		select {
 		case c := <-msg.Configs:
 			configs = c
@@ -37,7 +37,7 @@ func TestUpdateTopicConfig(t *testing.T) {
 			assert.Fail(t, "Failed to list configs", e)
 			return
 		}
-		assert.Equal(t, "172800000", configs["delete.retention.ms"])
+		assert.Equal(t, "172800000", configs["delete.retention.ms"].Value)
 
 		// clean up
 		ka.DeleteTopic(topic)
@@ -56,10 +56,159 @@ func TestUpdateTopicConfig(t *testing.T) {
 
 		// when
 		msg := ka.UpdateConfig(TopicConfigToUpdate{
-			topic,
-			"delete.retention.ms",
-			"-172800000"},
-		)
+			Topic: topic,
+			Key:   "delete.retention.ms",
+			Value: "-172800000",
+		})
+
+		// then
+		assert.IsType(t, KAdminErrorMsg{}, msg)
+		assert.Equal(t, "kafka server: Configuration is invalid - Invalid value -172800000 for configuration delete.retention.ms: Value must be at least 0", msg.(KAdminErrorMsg).Error.Error())
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Keep other overrides", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+				ConfigEntries: []kgo.ConfigEntry{
+					{ConfigName: "retention.ms", ConfigValue: "3600000"},
+				},
+			},
+		})
+
+		// when
+		ka.UpdateConfig(TopicConfigToUpdate{
+			Topic: topic,
+			Key:   "delete.retention.ms",
+			Value: "172800000",
+		})
+
+		// then
+		configs := listConfigs(t, topic)
+		assert.Equal(t, "172800000", configs["delete.retention.ms"].Value)
+		assert.Equal(t, "3600000", configs["retention.ms"].Value)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Revert to default", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+				ConfigEntries: []kgo.ConfigEntry{
+					{ConfigName: "retention.ms", ConfigValue: "3600000"},
+				},
+			},
+		})
+
+		// when
+		msg := ka.UpdateConfig(TopicConfigToUpdate{
+			Topic:     topic,
+			Key:       "retention.ms",
+			Operation: DeleteConfig,
+		})
+
+		// then
+		assert.IsType(t, TopicConfigUpdatedMsg{}, msg)
+		assert.Equal(t, "604800000", listConfigs(t, topic)["retention.ms"].Value)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Append and subtract list values", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+			},
+		})
+
+		// when
+		ka.UpdateConfig(TopicConfigToUpdate{
+			Topic:     topic,
+			Key:       "cleanup.policy",
+			Value:     "compact",
+			Operation: AppendConfig,
+		})
+
+		// then
+		assert.Equal(t, "delete,compact", listConfigs(t, topic)["cleanup.policy"].Value)
+
+		// when
+		ka.UpdateConfig(TopicConfigToUpdate{
+			Topic:     topic,
+			Key:       "cleanup.policy",
+			Value:     "delete",
+			Operation: SubtractConfig,
+		})
+
+		// then
+		assert.Equal(t, "compact", listConfigs(t, topic)["cleanup.policy"].Value)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Validate without applying", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+			},
+		})
+		toUpdate := TopicConfigToUpdate{
+			Topic: topic,
+			Key:   "delete.retention.ms",
+			Value: "172800000",
+		}
+
+		// when
+		msg := ka.ValidateConfig(toUpdate)
+
+		// then
+		assert.Equal(t, TopicConfigValidatedMsg{toUpdate}, msg)
+		assert.Equal(t, "86400000", listConfigs(t, topic)["delete.retention.ms"].Value)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Validate invalid value", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+			},
+		})
+
+		// when
+		msg := ka.ValidateConfig(TopicConfigToUpdate{
+			Topic: topic,
+			Key:   "delete.retention.ms",
+			Value: "-172800000",
+		})
 
 		// then
 		assert.IsType(t, KAdminErrorMsg{}, msg)
@@ -87,3 +236,14 @@ func TestUpdateTopicConfig(t *testing.T) {
 	//	assert.Equal(t, "Broker Not Available: not a client facing error and is used mostly by tools when a broker is not alive", msg.(KAdminErrorMsg).Error.Error())
 	//})
 }
+
+func listConfigs(t *testing.T, topic string) map[string]TopicConfig {
+	msg := ka.ListConfigs(topic).(TopicConfigListingStartedMsg)
+	select {
+	case c := <-msg.Configs:
+		return c
+	case e := <-msg.Err:
+		assert.Fail(t, "Failed to list configs", e)
+		return nil
+	}
+}
@@ -19,6 +19,9 @@ const (
 type ConnectionDetails struct {
 	BootstrapServers []string
 	SASLConfig       *SASLConfig
+	TopicFormats     []config.TopicFormat
+	// ValidateJsonSchema validates JSON payloads against their registered schema
+	ValidateJsonSchema bool
 }
 
 type SASLProtocol int
@@ -33,6 +36,8 @@ type GroupMember struct {
 	MemberId   string
 	ClientId   string
 	ClientHost string
+	// Assignment holds the partitions per topic the member consumes from.
+	Assignment map[string][]int32
 }
 
 type KAdminErrorMsg struct {
@@ -43,14 +48,24 @@ type Kadmin interface {
 	TopicCreator
 	TopicDeleter
 	TopicLister
+	TopicDescriber
+	TopicStatsLister
+	PartitionCreator
+	PartitionReassigner
+	BrokerLister
+	RecordDeleter
 	Publisher
 	RecordReader
 	OffsetLister
+	OffsetResetter
 	CGroupLister
+	TopicCGroupLister
 	CGroupDeleter
 	ConfigUpdater
 	TopicConfigLister
 	SraSetter
+	// Close releases the connections to the cluster.
+	Close() error
 }
 
 type Instantiator func(cd ConnectionDetails) (Kadmin, error)
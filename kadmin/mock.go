@@ -21,10 +21,42 @@ func (m MockKadmin) ListTopics() tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) DescribeTopic(topic string) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ListTopicStats(ctx context.Context, topics []string) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) CreatePartitions(pcd PartitionCreationDetails) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ReassignPartitions(topic string, assignment map[int32][]int32) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ListPartitionReassignments(topic string) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ListBrokers() tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) DeleteRecords(rdd RecordDeletionDetails) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) PublishRecord(p *ProducerRecord) PublicationStartedMsg {
 	return PublicationStartedMsg{}
 }
 
+func (m MockKadmin) PublishRecords(ctx context.Context, records []ProducerRecord) BulkPublicationStartedMsg {
+	return BulkPublicationStartedMsg{}
+}
+
 func (m MockKadmin) ReadRecords(ctx context.Context, rd ReadDetails) tea.Msg {
 	return ReadingStartedMsg{}
 }
@@ -33,10 +65,22 @@ func (m MockKadmin) ListOffsets(group string) tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) PreviewOffsetReset(details OffsetResetDetails) tea.Msg {
+	return nil
+}
+
+func (m MockKadmin) ResetOffsets(preview OffsetResetPreview) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) ListCGroups() tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) ListTopicCGroups(topic string) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) DeleteCGroup(name string) tea.Msg {
 	return nil
 }
@@ -45,6 +89,10 @@ func (m MockKadmin) UpdateConfig(t TopicConfigToUpdate) tea.Msg {
 	return nil
 }
 
+func (m MockKadmin) ValidateConfig(t TopicConfigToUpdate) tea.Msg {
+	return nil
+}
+
 func (m MockKadmin) ListConfigs(topic string) tea.Msg {
 	return nil
 }
@@ -52,6 +100,10 @@ func (m MockKadmin) ListConfigs(topic string) tea.Msg {
 func (m MockKadmin) SetSra(sra sradmin.SrAdmin) {
 }
 
+func (m MockKadmin) Close() error {
+	return nil
+}
+
 func NewMockKadminInstantiator() Instantiator {
 	return func(cd ConnectionDetails) (Kadmin, error) {
 		return &MockKadmin{}, nil
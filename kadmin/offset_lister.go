@@ -1,15 +1,29 @@
 package kadmin
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"maps"
+	"sync"
+)
+
+// UnknownLag is the lag of partitions of which the committed offset or the high watermark is not known.
+const UnknownLag = -1
+
+// maxConcurrentGroupRequests bounds the requests sent at once when fetching the details of many groups.
+const maxConcurrentGroupRequests = 10
 
 type OffsetLister interface {
 	ListOffsets(group string) tea.Msg
 }
 
 type TopicPartitionOffset struct {
-	Topic     string
-	Partition int32
-	Offset    int64
+	Topic         string
+	Partition     int32
+	Offset        int64
+	HighWatermark int64
+	Lag           int64
 }
 
 type OffsetListingStartedMsg struct {
@@ -48,21 +62,144 @@ func (ka *SaramaKafkaAdmin) ListOffsets(group string) tea.Msg {
 
 func (ka *SaramaKafkaAdmin) doListOffsets(group string, offsetsChan chan []TopicPartitionOffset, errChan chan error) {
 	maybeIntroduceLatency()
-	listResult, err := ka.admin.ListConsumerGroupOffsets(group, nil)
+	topicPartitionOffsets, err := ka.committedOffsets(group)
 	if err != nil {
 		errChan <- err
+		return
+	}
+
+	ka.determineLags(topicPartitionOffsets)
+
+	offsetsChan <- topicPartitionOffsets
+}
+
+func (ka *SaramaKafkaAdmin) committedOffsets(group string) ([]TopicPartitionOffset, error) {
+	listResult, err := ka.admin.ListConsumerGroupOffsets(group, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	var topicPartitionOffsets []TopicPartitionOffset
 	for t, m := range listResult.Blocks {
 		for p, block := range m {
 			topicPartitionOffsets = append(topicPartitionOffsets, TopicPartitionOffset{
-				Topic:     t,
-				Partition: p,
-				Offset:    block.Offset,
+				Topic:         t,
+				Partition:     p,
+				Offset:        block.Offset,
+				HighWatermark: UnknownLag,
+				Lag:           UnknownLag,
 			})
 		}
 	}
+	return topicPartitionOffsets, nil
+}
 
-	offsetsChan <- topicPartitionOffsets
+// committedOffsetsOfGroups lists the committed offsets of the groups concurrently,
+// groups of which the offsets cannot be listed are left out.
+func (ka *SaramaKafkaAdmin) committedOffsetsOfGroups(groups []string) map[string][]TopicPartitionOffset {
+	offsets := make([][]TopicPartitionOffset, len(groups))
+	errs := make([]error, len(groups))
+	forEachConcurrently(groups, func(i int, group string) {
+		offsets[i], errs[i] = ka.committedOffsets(group)
+	})
+
+	offsetsByGroup := make(map[string][]TopicPartitionOffset, len(groups))
+	for i, group := range groups {
+		if errs[i] != nil {
+			log.Warn("Unable to list committed offsets", "group", group, "err", errs[i])
+			continue
+		}
+		offsetsByGroup[group] = offsets[i]
+	}
+	return offsetsByGroup
+}
+
+// forEachConcurrently calls fn for every item, running at most maxConcurrentGroupRequests calls
+// at once, and returns when all calls are done.
+func forEachConcurrently[T any](items []T, fn func(i int, item T)) {
+	var wg sync.WaitGroup
+	running := make(chan struct{}, maxConcurrentGroupRequests)
+	for i, item := range items {
+		wg.Add(1)
+		running <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-running }()
+			fn(i, item)
+		}()
+	}
+	wg.Wait()
+}
+
+// determineLags fills in the high watermarks and lags of the offsets of one or more groups,
+// leaving them unknown when the high watermarks cannot be listed.
+func (ka *SaramaKafkaAdmin) determineLags(offsets ...[]TopicPartitionOffset) {
+	var partitions []topicPartition
+	seen := make(map[topicPartition]bool)
+	for _, groupOffsets := range offsets {
+		for _, o := range groupOffsets {
+			tp := topicPartition{o.Topic, o.Partition}
+			if !seen[tp] {
+				seen[tp] = true
+				partitions = append(partitions, tp)
+			}
+		}
+	}
+
+	highs, err := ka.offsetsAt(partitions, sarama.OffsetNewest)
+	if err != nil {
+		log.Warn("Unable to list high watermarks", "err", err)
+		return
+	}
+
+	for _, groupOffsets := range offsets {
+		for i, o := range groupOffsets {
+			high, ok := highs[topicPartition{o.Topic, o.Partition}]
+			if !ok {
+				continue
+			}
+			groupOffsets[i].HighWatermark = high
+			if o.Offset >= 0 {
+				groupOffsets[i].Lag = max(high-o.Offset, 0)
+			}
+		}
+	}
+}
+
+// offsetsAt requests the offsets at the given time of all partitions led by the same broker at once,
+// time being a timestamp in millis, sarama.OffsetOldest or sarama.OffsetNewest.
+func (ka *SaramaKafkaAdmin) offsetsAt(partitions []topicPartition, time int64) (map[topicPartition]int64, error) {
+	leaders := make(map[int32]*sarama.Broker)
+	partitionsByLeader := make(map[int32][]topicPartition)
+	for _, tp := range partitions {
+		leader, err := ka.client.Leader(tp.topic, tp.partition)
+		if err != nil {
+			log.Warn("Unable to determine leader", "topic", tp.topic, "partition", tp.partition, "err", err)
+			continue
+		}
+		leaders[leader.ID()] = leader
+		partitionsByLeader[leader.ID()] = append(partitionsByLeader[leader.ID()], tp)
+	}
+
+	offsets := make(map[topicPartition]int64)
+	for id, leaderPartitions := range partitionsByLeader {
+		leaderOffsets, err := listOffsets(leaders[id], leaderPartitions, time)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(offsets, leaderOffsets)
+	}
+	return offsets, nil
+}
+
+// TotalLag sums the known lags, it is UnknownLag when none of them are known.
+func TotalLag(offsets []TopicPartitionOffset) int64 {
+	var total int64 = UnknownLag
+	for _, o := range offsets {
+		if o.Lag == UnknownLag {
+			continue
+		}
+		total = max(total, 0) + o.Lag
+	}
+	return total
 }
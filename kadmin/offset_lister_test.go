@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/IBM/sarama"
 	"github.com/stretchr/testify/assert"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -27,8 +28,8 @@ func TestConsumerGroupOffsets(t *testing.T) {
 
 		for i := 0; i < 10; i++ {
 			ka.PublishRecord(&ProducerRecord{
-				Key:       "key",
-				Value:     "value",
+				Key:       []byte("key"),
+				Value:     []byte("value"),
 				Topic:     topic,
 				Partition: nil,
 			})
@@ -52,9 +53,11 @@ func TestConsumerGroupOffsets(t *testing.T) {
 			assert.NotNil(t, offsets)
 			assert.Len(t, offsets, 1)
 			assert.Equal(t, offsets[0], TopicPartitionOffset{
-				Topic:     topic,
-				Partition: 0,
-				Offset:    9,
+				Topic:         topic,
+				Partition:     0,
+				Offset:        9,
+				HighWatermark: 10,
+				Lag:           1,
 			})
 		case err := <-offsetListingStartedMsg.Err:
 			t.Fatal("Error while listing offsets", err)
@@ -63,3 +66,51 @@ func TestConsumerGroupOffsets(t *testing.T) {
 		}
 	})
 }
+
+func TestTotalLag(t *testing.T) {
+	t.Run("Sum known lags", func(t *testing.T) {
+		assert.Equal(t, int64(15), TotalLag([]TopicPartitionOffset{
+			{Topic: "topic", Partition: 0, Lag: 10},
+			{Topic: "topic", Partition: 1, Lag: UnknownLag},
+			{Topic: "topic", Partition: 2, Lag: 5},
+		}))
+	})
+
+	t.Run("Unknown when no lag is known", func(t *testing.T) {
+		assert.Equal(t, int64(UnknownLag), TotalLag([]TopicPartitionOffset{
+			{Topic: "topic", Partition: 0, Lag: UnknownLag},
+		}))
+		assert.Equal(t, int64(UnknownLag), TotalLag(nil))
+	})
+
+	t.Run("No lag", func(t *testing.T) {
+		assert.Equal(t, int64(0), TotalLag([]TopicPartitionOffset{
+			{Topic: "topic", Partition: 0, Lag: 0},
+		}))
+	})
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	items := make([]int, 3*maxConcurrentGroupRequests)
+	for i := range items {
+		items[i] = i * 2
+	}
+
+	var running, maxRunning atomic.Int32
+	results := make([]int, len(items))
+	forEachConcurrently(items, func(i int, item int) {
+		r := running.Add(1)
+		for {
+			m := maxRunning.Load()
+			if r <= m || maxRunning.CompareAndSwap(m, r) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		results[i] = item
+		running.Add(-1)
+	})
+
+	assert.Equal(t, items, results)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(maxConcurrentGroupRequests))
+}
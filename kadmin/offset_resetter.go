@@ -0,0 +1,310 @@
+package kadmin
+
+import (
+	"errors"
+	"fmt"
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+	"sort"
+)
+
+type OffsetResetter interface {
+	// PreviewOffsetReset determines the offsets a reset would commit without committing them.
+	PreviewOffsetReset(details OffsetResetDetails) tea.Msg
+	ResetOffsets(preview OffsetResetPreview) tea.Msg
+}
+
+type OffsetResetTarget int
+
+const (
+	ResetToEarliest OffsetResetTarget = iota
+	ResetToLatest
+	ResetToTimestamp
+	ResetToOffset
+	ResetByShift
+)
+
+type OffsetResetDetails struct {
+	Group string
+	// Partitions limits the reset to the given partitions per topic, to all partitions
+	// of a topic when they are empty or to all partitions of the group when nil.
+	Partitions map[string][]int32
+	Target     OffsetResetTarget
+	// Timestamp in millis to reset to when the Target is ResetToTimestamp.
+	Timestamp int64
+	// Offset to reset to when the Target is ResetToOffset,
+	// or the number of offsets to shift by when it is ResetByShift.
+	Offset int64
+}
+
+type OffsetReset struct {
+	Topic     string
+	Partition int32
+	OldOffset int64
+	NewOffset int64
+}
+
+type OffsetResetPreview struct {
+	Group  string
+	Resets []OffsetReset
+	// ActiveMembers is the number of members of the group, offsets can only be reset when there are none.
+	ActiveMembers int
+}
+
+type OffsetResetPreviewStartedMsg struct {
+	Preview chan OffsetResetPreview
+	Err     chan error
+}
+
+type OffsetResetPreviewedMsg struct {
+	Preview OffsetResetPreview
+}
+
+type OffsetResetPreviewErrMsg struct {
+	Err error
+}
+
+func (m *OffsetResetPreviewStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case preview := <-m.Preview:
+		return OffsetResetPreviewedMsg{preview}
+	case err := <-m.Err:
+		return OffsetResetPreviewErrMsg{err}
+	}
+}
+
+type OffsetResetStartedMsg struct {
+	Reset chan bool
+	Err   chan error
+}
+
+type OffsetsResetMsg struct{}
+
+type OffsetResetErrMsg struct {
+	Err error
+}
+
+func (m *OffsetResetStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case <-m.Reset:
+		return OffsetsResetMsg{}
+	case err := <-m.Err:
+		return OffsetResetErrMsg{err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) PreviewOffsetReset(details OffsetResetDetails) tea.Msg {
+	previewChan := make(chan OffsetResetPreview)
+	errChan := make(chan error)
+
+	go ka.doPreviewOffsetReset(details, previewChan, errChan)
+
+	return OffsetResetPreviewStartedMsg{previewChan, errChan}
+}
+
+func (ka *SaramaKafkaAdmin) doPreviewOffsetReset(
+	details OffsetResetDetails,
+	previewChan chan OffsetResetPreview,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+	activeMembers, err := ka.activeMembers(details.Group)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	committed, err := ka.committedOffsets(details.Group)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	var partitions []topicPartition
+	oldOffsets := make(map[topicPartition]int64)
+	for _, o := range committed {
+		if !isSelected(details.Partitions, o.Topic, o.Partition) {
+			continue
+		}
+		tp := topicPartition{o.Topic, o.Partition}
+		partitions = append(partitions, tp)
+		oldOffsets[tp] = o.Offset
+	}
+	if len(partitions) == 0 {
+		errChan <- errors.New("no committed offsets to reset")
+		return
+	}
+
+	lows, err := ka.offsetsAt(partitions, sarama.OffsetOldest)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	highs, err := ka.offsetsAt(partitions, sarama.OffsetNewest)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	var atTimestamp map[topicPartition]int64
+	if details.Target == ResetToTimestamp {
+		atTimestamp, err = ka.offsetsAt(partitions, details.Timestamp)
+		if err != nil {
+			errChan <- err
+			return
+		}
+	}
+
+	resets, err := offsetResets(details, partitions, oldOffsets, lows, highs, atTimestamp)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	previewChan <- OffsetResetPreview{
+		Group:         details.Group,
+		Resets:        resets,
+		ActiveMembers: activeMembers,
+	}
+}
+
+// offsetResets determines the new offset of each partition, sorted by topic and partition.
+// Partitions missing in the listed offsets failed to be looked up.
+func offsetResets(
+	details OffsetResetDetails,
+	partitions []topicPartition,
+	oldOffsets, lows, highs, atTimestamp map[topicPartition]int64,
+) ([]OffsetReset, error) {
+	var resets []OffsetReset
+	for _, tp := range partitions {
+		low, lowOk := lows[tp]
+		high, highOk := highs[tp]
+		if !lowOk || !highOk {
+			return nil, fmt.Errorf("unable to determine the offsets of partition %d of %s", tp.partition, tp.topic)
+		}
+		// a successful lookup without records at or after the timestamp is -1
+		offsetAtTimestamp, ok := atTimestamp[tp]
+		if details.Target == ResetToTimestamp && !ok {
+			return nil, fmt.Errorf("unable to determine the offset at the timestamp of partition %d of %s", tp.partition, tp.topic)
+		}
+		resets = append(resets, OffsetReset{
+			Topic:     tp.topic,
+			Partition: tp.partition,
+			OldOffset: oldOffsets[tp],
+			NewOffset: resetOffset(details, oldOffsets[tp], low, high, offsetAtTimestamp),
+		})
+	}
+	sort.Slice(resets, func(i, j int) bool {
+		if resets[i].Topic != resets[j].Topic {
+			return resets[i].Topic < resets[j].Topic
+		}
+		return resets[i].Partition < resets[j].Partition
+	})
+	return resets, nil
+}
+
+func isSelected(selection map[string][]int32, topic string, partition int32) bool {
+	if selection == nil {
+		return true
+	}
+	partitions, ok := selection[topic]
+	if !ok {
+		return false
+	}
+	return len(partitions) == 0 || slices.Contains(partitions, partition)
+}
+
+// resetOffset determines the offset to reset to, which always lies between the low and high watermark.
+func resetOffset(details OffsetResetDetails, current, low, high, atTimestamp int64) int64 {
+	var offset int64
+	switch details.Target {
+	case ResetToEarliest:
+		return low
+	case ResetToLatest:
+		return high
+	case ResetToTimestamp:
+		// there are no records at or after the timestamp
+		if atTimestamp < 0 {
+			return high
+		}
+		offset = atTimestamp
+	case ResetToOffset:
+		offset = details.Offset
+	case ResetByShift:
+		offset = current + details.Offset
+	}
+	return min(max(offset, low), high)
+}
+
+func (ka *SaramaKafkaAdmin) activeMembers(group string) (int, error) {
+	groups, err := ka.admin.DescribeConsumerGroups([]string{group})
+	if err != nil {
+		return 0, err
+	}
+	if len(groups) == 0 {
+		return 0, nil
+	}
+	if groups[0].Err != sarama.ErrNoError {
+		return 0, groups[0].Err
+	}
+	return len(groups[0].Members), nil
+}
+
+func (ka *SaramaKafkaAdmin) ResetOffsets(preview OffsetResetPreview) tea.Msg {
+	resetChan := make(chan bool)
+	errChan := make(chan error)
+
+	go ka.doResetOffsets(preview, resetChan, errChan)
+
+	return OffsetResetStartedMsg{resetChan, errChan}
+}
+
+func (ka *SaramaKafkaAdmin) doResetOffsets(preview OffsetResetPreview, resetChan chan bool, errChan chan error) {
+	maybeIntroduceLatency()
+	// members might have joined since the preview, committing would interfere with their progress
+	activeMembers, err := ka.activeMembers(preview.Group)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if activeMembers > 0 {
+		errChan <- fmt.Errorf(
+			"%s has %d active members, stop its consumers before resetting offsets",
+			preview.Group,
+			activeMembers,
+		)
+		return
+	}
+
+	coordinator, err := ka.client.Coordinator(preview.Group)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	request := &sarama.OffsetCommitRequest{
+		Version:                 2,
+		ConsumerGroup:           preview.Group,
+		ConsumerGroupGeneration: sarama.GroupGenerationUndefined,
+		RetentionTime:           -1,
+	}
+	for _, r := range preview.Resets {
+		request.AddBlock(r.Topic, r.Partition, r.NewOffset, 0, "")
+	}
+
+	response, err := coordinator.CommitOffset(request)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	for topic, partitions := range response.Errors {
+		for partition, kerr := range partitions {
+			if kerr != sarama.ErrNoError {
+				errChan <- fmt.Errorf("unable to reset partition %d of %s: %w", partition, topic, kerr)
+				return
+			}
+		}
+	}
+
+	resetChan <- true
+}
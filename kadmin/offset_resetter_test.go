@@ -0,0 +1,208 @@
+package kadmin
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOffsetResetter(t *testing.T) {
+	consume := func(t *testing.T, topic string, group string) {
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     1,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+		switch msg := msg.AwaitCompletion().(type) {
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		for i := 0; i < 10; i++ {
+			ka.PublishRecord(&ProducerRecord{
+				Key:   []byte("key"),
+				Value: []byte("value"),
+				Topic: topic,
+			})
+		}
+
+		consumerGroup, err := sarama.NewConsumerGroupFromClient(group, kafkaClient())
+		if err != nil {
+			t.Fatal("Unable to create Consumer Group.", err)
+		}
+		handler := testConsumer{ExpectedMsgCount: 10}
+		consumerGroup.Consume(context.WithoutCancel(context.Background()), []string{topic}, &handler)
+		consumerGroup.Close()
+	}
+
+	preview := func(t *testing.T, details OffsetResetDetails) OffsetResetPreview {
+		msg := ka.PreviewOffsetReset(details).(OffsetResetPreviewStartedMsg)
+		switch msg := msg.AwaitCompletion().(type) {
+		case OffsetResetPreviewedMsg:
+			return msg.Preview
+		case OffsetResetPreviewErrMsg:
+			t.Fatal("Unable to preview offset reset", msg.Err)
+		}
+		return OffsetResetPreview{}
+	}
+
+	t.Run("Preview and reset to earliest", func(t *testing.T) {
+		topic := topicName()
+		group := "reset-earliest-group"
+		consume(t, topic, group)
+
+		p := preview(t, OffsetResetDetails{
+			Group:  group,
+			Target: ResetToEarliest,
+		})
+
+		assert.Equal(t, OffsetResetPreview{
+			Group: group,
+			Resets: []OffsetReset{
+				{Topic: topic, Partition: 0, OldOffset: 9, NewOffset: 0},
+			},
+		}, p)
+
+		msg := ka.ResetOffsets(p).(OffsetResetStartedMsg)
+		assert.IsType(t, OffsetsResetMsg{}, msg.AwaitCompletion())
+
+		offsets := ka.ListOffsets(group).(OffsetListingStartedMsg)
+		assert.Equal(t, int64(0), (<-offsets.Offsets)[0].Offset)
+
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Preview shift without resetting", func(t *testing.T) {
+		topic := topicName()
+		group := "reset-shift-group"
+		consume(t, topic, group)
+
+		p := preview(t, OffsetResetDetails{
+			Group:      group,
+			Partitions: map[string][]int32{topic: {0}},
+			Target:     ResetByShift,
+			Offset:     -5,
+		})
+
+		assert.Equal(t, int64(4), p.Resets[0].NewOffset)
+		offsets := ka.ListOffsets(group).(OffsetListingStartedMsg)
+		assert.Equal(t, int64(9), (<-offsets.Offsets)[0].Offset)
+
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Refuse to reset with active members", func(t *testing.T) {
+		topic := topicName()
+		group := "reset-active-group"
+		consume(t, topic, group)
+
+		consumerGroup, err := sarama.NewConsumerGroupFromClient(group, kafkaClient())
+		if err != nil {
+			t.Fatal("Unable to create Consumer Group.", err)
+		}
+		defer consumerGroup.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go consumerGroup.Consume(ctx, []string{topic}, &testConsumer{ExpectedMsgCount: 100})
+
+		assert.Eventually(t, func() bool {
+			return preview(t, OffsetResetDetails{Group: group, Target: ResetToLatest}).ActiveMembers == 1
+		}, 10*time.Second, 100*time.Millisecond)
+
+		msg := ka.ResetOffsets(OffsetResetPreview{
+			Group:  group,
+			Resets: []OffsetReset{{Topic: topic, Partition: 0, OldOffset: 9, NewOffset: 0}},
+		}).(OffsetResetStartedMsg)
+
+		switch msg := msg.AwaitCompletion().(type) {
+		case OffsetResetErrMsg:
+			assert.Equal(t, group+" has 1 active members, stop its consumers before resetting offsets", msg.Err.Error())
+		default:
+			t.Fatal("Expected the reset to be refused")
+		}
+
+		ka.DeleteTopic(topic)
+	})
+}
+
+func TestResetOffset(t *testing.T) {
+	const low, high = 10, 100
+
+	tests := []struct {
+		name        string
+		details     OffsetResetDetails
+		current     int64
+		atTimestamp int64
+		expected    int64
+	}{
+		{"earliest", OffsetResetDetails{Target: ResetToEarliest}, 50, -1, 10},
+		{"latest", OffsetResetDetails{Target: ResetToLatest}, 50, -1, 100},
+		{"timestamp", OffsetResetDetails{Target: ResetToTimestamp}, 50, 42, 42},
+		{"timestamp after the last record", OffsetResetDetails{Target: ResetToTimestamp}, 50, -1, 100},
+		{"offset", OffsetResetDetails{Target: ResetToOffset, Offset: 20}, 50, -1, 20},
+		{"offset before the low watermark", OffsetResetDetails{Target: ResetToOffset, Offset: 5}, 50, -1, 10},
+		{"offset after the high watermark", OffsetResetDetails{Target: ResetToOffset, Offset: 500}, 50, -1, 100},
+		{"shift back", OffsetResetDetails{Target: ResetByShift, Offset: -30}, 50, -1, 20},
+		{"shift forward", OffsetResetDetails{Target: ResetByShift, Offset: 30}, 50, -1, 80},
+		{"shift beyond the high watermark", OffsetResetDetails{Target: ResetByShift, Offset: 300}, 50, -1, 100},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, resetOffset(test.details, test.current, low, high, test.atTimestamp))
+		})
+	}
+}
+
+func TestOffsetResets(t *testing.T) {
+	p0 := topicPartition{"topic", 0}
+	p1 := topicPartition{"topic", 1}
+	partitions := []topicPartition{p1, p0}
+	oldOffsets := map[topicPartition]int64{p0: 50, p1: 50}
+	lows := map[topicPartition]int64{p0: 10, p1: 10}
+	highs := map[topicPartition]int64{p0: 100, p1: 100}
+	details := OffsetResetDetails{Target: ResetToTimestamp}
+
+	t.Run("Reset to latest when there are no records after the timestamp", func(t *testing.T) {
+		atTimestamp := map[topicPartition]int64{p0: 42, p1: -1}
+
+		resets, err := offsetResets(details, partitions, oldOffsets, lows, highs, atTimestamp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []OffsetReset{
+			{Topic: "topic", Partition: 0, OldOffset: 50, NewOffset: 42},
+			{Topic: "topic", Partition: 1, OldOffset: 50, NewOffset: 100},
+		}, resets)
+	})
+
+	t.Run("Fail when the offset at the timestamp could not be looked up", func(t *testing.T) {
+		atTimestamp := map[topicPartition]int64{p0: 42}
+
+		_, err := offsetResets(details, partitions, oldOffsets, lows, highs, atTimestamp)
+
+		assert.EqualError(t, err, "unable to determine the offset at the timestamp of partition 1 of topic")
+	})
+
+	t.Run("Fail when the watermarks could not be looked up", func(t *testing.T) {
+		_, err := offsetResets(
+			OffsetResetDetails{Target: ResetToEarliest},
+			partitions,
+			oldOffsets,
+			map[topicPartition]int64{p0: 10},
+			highs,
+			nil,
+		)
+
+		assert.EqualError(t, err, "unable to determine the offsets of partition 1 of topic")
+	})
+}
+
+func TestIsSelected(t *testing.T) {
+	assert.True(t, isSelected(nil, "topic", 1))
+	assert.True(t, isSelected(map[string][]int32{"topic": nil}, "topic", 1))
+	assert.True(t, isSelected(map[string][]int32{"topic": {1, 2}}, "topic", 1))
+	assert.False(t, isSelected(map[string][]int32{"topic": {2}}, "topic", 1))
+	assert.False(t, isSelected(map[string][]int32{"other": nil}, "topic", 1))
+}
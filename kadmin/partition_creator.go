@@ -0,0 +1,59 @@
+package kadmin
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type PartitionCreator interface {
+	CreatePartitions(pcd PartitionCreationDetails) tea.Msg
+}
+
+type PartitionCreationDetails struct {
+	Topic string
+	// NumPartitions is the total number of partitions the topic should have afterward.
+	NumPartitions int
+}
+
+type PartitionsCreatedMsg struct {
+}
+
+type PartitionCreationErrMsg struct {
+	Err error
+}
+
+type PartitionCreationStartedMsg struct {
+	Created chan bool
+	Err     chan error
+}
+
+func (msg *PartitionCreationStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case <-msg.Created:
+		return PartitionsCreatedMsg{}
+	case err := <-msg.Err:
+		return PartitionCreationErrMsg{Err: err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) CreatePartitions(pcd PartitionCreationDetails) tea.Msg {
+	created := make(chan bool)
+	err := make(chan error)
+
+	go ka.doCreatePartitions(pcd, created, err)
+
+	return PartitionCreationStartedMsg{
+		Created: created,
+		Err:     err,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doCreatePartitions(pcd PartitionCreationDetails, created chan bool, errChan chan error) {
+	maybeIntroduceLatency()
+	// the brokers assign the replicas of the new partitions
+	err := ka.admin.CreatePartitions(pcd.Topic, int32(pcd.NumPartitions), nil, false)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	created <- true
+}
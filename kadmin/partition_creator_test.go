@@ -0,0 +1,83 @@
+package kadmin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCreatePartitions(t *testing.T) {
+	t.Run("Increase partition count", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		// when
+		pcm := ka.CreatePartitions(PartitionCreationDetails{
+			Topic:         topic,
+			NumPartitions: 5,
+		}).(PartitionCreationStartedMsg)
+
+		// then
+		switch msg := pcm.AwaitCompletion().(type) {
+		case PartitionsCreatedMsg:
+		case PartitionCreationErrMsg:
+			t.Fatal("Unable to create partitions", msg.Err)
+		}
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			listTopicsMsg := ka.ListTopics().(TopicListingStartedMsg)
+			var topics []Topic
+			select {
+			case topics = <-listTopicsMsg.Topics:
+			case err := <-listTopicsMsg.Err:
+				t.Error(t, "Failed to list topics", err)
+				return
+			}
+			assert.Contains(c, topics, Topic{Name: topic, Partitions: 5, Replicas: 1, Isr: 5})
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Decreasing partition count fails", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		// when
+		pcm := ka.CreatePartitions(PartitionCreationDetails{
+			Topic:         topic,
+			NumPartitions: 1,
+		}).(PartitionCreationStartedMsg)
+
+		// then
+		assert.IsType(t, PartitionCreationErrMsg{}, pcm.AwaitCompletion())
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+}
@@ -0,0 +1,220 @@
+package kadmin
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"slices"
+	"sort"
+)
+
+type PartitionReassigner interface {
+	// ReassignPartitions moves the replicas of the partitions of the topic to the brokers in the assignment,
+	// the replicas of every partition need to be present, including the ones that do not change.
+	ReassignPartitions(topic string, assignment map[int32][]int32) tea.Msg
+	ListPartitionReassignments(topic string) tea.Msg
+}
+
+// PartitionReassignment is a reassignment in progress, Replicas includes the replicas being added and removed.
+type PartitionReassignment struct {
+	Replicas         []int32
+	AddingReplicas   []int32
+	RemovingReplicas []int32
+}
+
+type PartitionReassignmentStartedMsg struct {
+	Reassigned chan bool
+	Err        chan error
+}
+
+type PartitionsReassignedMsg struct{}
+
+type PartitionReassignmentErrMsg struct {
+	Err error
+}
+
+func (m *PartitionReassignmentStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case <-m.Reassigned:
+		return PartitionsReassignedMsg{}
+	case err := <-m.Err:
+		return PartitionReassignmentErrMsg{err}
+	}
+}
+
+type PartitionReassignmentsListingStartedMsg struct {
+	Err           chan error
+	Reassignments chan map[int32]PartitionReassignment
+}
+
+type PartitionReassignmentsListedMsg struct {
+	Reassignments map[int32]PartitionReassignment
+}
+
+type PartitionReassignmentsListingErrorMsg struct {
+	Err error
+}
+
+func (m *PartitionReassignmentsListingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case reassignments := <-m.Reassignments:
+		return PartitionReassignmentsListedMsg{reassignments}
+	case err := <-m.Err:
+		return PartitionReassignmentsListingErrorMsg{err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) ReassignPartitions(topic string, assignment map[int32][]int32) tea.Msg {
+	reassigned := make(chan bool)
+	errChan := make(chan error)
+
+	go ka.doReassignPartitions(topic, assignment, reassigned, errChan)
+
+	return PartitionReassignmentStartedMsg{
+		reassigned,
+		errChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doReassignPartitions(
+	topic string,
+	assignment map[int32][]int32,
+	reassigned chan bool,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+	// a partition without replicas cancels its reassignment, so all of them are required
+	replicas := make([][]int32, len(assignment))
+	for partition := range replicas {
+		r, ok := assignment[int32(partition)]
+		if !ok || len(r) == 0 {
+			errChan <- fmt.Errorf("missing replicas of partition %d", partition)
+			return
+		}
+		replicas[partition] = r
+	}
+
+	if err := ka.admin.AlterPartitionReassignments(topic, replicas); err != nil {
+		errChan <- err
+		return
+	}
+	reassigned <- true
+}
+
+func (ka *SaramaKafkaAdmin) ListPartitionReassignments(topic string) tea.Msg {
+	errChan := make(chan error)
+	reassignmentsChan := make(chan map[int32]PartitionReassignment)
+
+	go ka.doListPartitionReassignments(topic, reassignmentsChan, errChan)
+
+	return PartitionReassignmentsListingStartedMsg{
+		errChan,
+		reassignmentsChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doListPartitionReassignments(
+	topic string,
+	reassignmentsChan chan map[int32]PartitionReassignment,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+	partitions, err := ka.client.Partitions(topic)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	statuses, err := ka.admin.ListPartitionReassignments(topic, partitions)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	reassignments := make(map[int32]PartitionReassignment)
+	for partition, status := range statuses[topic] {
+		reassignments[partition] = PartitionReassignment{
+			Replicas:         status.Replicas,
+			AddingReplicas:   status.AddingReplicas,
+			RemovingReplicas: status.RemovingReplicas,
+		}
+	}
+	reassignmentsChan <- reassignments
+}
+
+// SpreadReplicas proposes an assignment with replicationFactor replicas per partition spread evenly over the brokers.
+// Current replicas are kept where possible to limit the data that needs to move, the first replica stays the
+// preferred leader. Replicas are removed from brokers holding the most replicas of the topic.
+func SpreadReplicas(current map[int32][]int32, brokers []int32, replicationFactor int) (map[int32][]int32, error) {
+	if replicationFactor < 1 {
+		return nil, fmt.Errorf("replication factor must be at least 1")
+	}
+	if replicationFactor > len(brokers) {
+		return nil, fmt.Errorf("replication factor %d exceeds the %d available brokers", replicationFactor, len(brokers))
+	}
+
+	load := make(map[int32]int)
+	for _, broker := range brokers {
+		load[broker] = 0
+	}
+
+	var partitions []int32
+	proposal := make(map[int32][]int32)
+	for partition, replicas := range current {
+		partitions = append(partitions, partition)
+		// replicas on brokers that are gone have to move anyway
+		var kept []int32
+		for _, replica := range replicas {
+			if _, ok := load[replica]; ok {
+				kept = append(kept, replica)
+				load[replica]++
+			}
+		}
+		proposal[partition] = kept
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	// shrink first, so growing other partitions can use the freed capacity
+	for _, partition := range partitions {
+		for len(proposal[partition]) > replicationFactor {
+			replicas := proposal[partition]
+			// never remove the preferred leader
+			busiest := 1
+			for i := 2; i < len(replicas); i++ {
+				if load[replicas[i]] > load[replicas[busiest]] {
+					busiest = i
+				}
+			}
+			load[replicas[busiest]]--
+			proposal[partition] = slices.Delete(slices.Clone(replicas), busiest, busiest+1)
+		}
+	}
+
+	for _, partition := range partitions {
+		candidates := rotateBrokers(brokers, proposal[partition], partition)
+		for len(proposal[partition]) < replicationFactor {
+			var idlest int32 = -1
+			for _, broker := range candidates {
+				if slices.Contains(proposal[partition], broker) {
+					continue
+				}
+				if idlest == -1 || load[broker] < load[idlest] {
+					idlest = broker
+				}
+			}
+			load[idlest]++
+			proposal[partition] = append(slices.Clone(proposal[partition]), idlest)
+		}
+	}
+	return proposal, nil
+}
+
+// rotateBrokers orders the brokers starting after the preferred leader, or after the partition when it has no
+// replicas, so equally loaded brokers are picked round-robin like Kafka does when creating a topic.
+func rotateBrokers(brokers []int32, replicas []int32, partition int32) []int32 {
+	start := int(partition)
+	if len(replicas) > 0 {
+		start = slices.Index(brokers, replicas[0])
+	}
+	start = (start + 1) % len(brokers)
+	return append(slices.Clone(brokers[start:]), brokers[:start]...)
+}
@@ -0,0 +1,139 @@
+package kadmin
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSpreadReplicas(t *testing.T) {
+	t.Run("Raise replication factor evenly", func(t *testing.T) {
+		current := map[int32][]int32{0: {1}, 1: {2}, 2: {3}}
+
+		proposal, err := SpreadReplicas(current, []int32{1, 2, 3}, 2)
+
+		assert.NoError(t, err)
+		load := make(map[int32]int)
+		for partition, replicas := range proposal {
+			assert.Len(t, replicas, 2)
+			assert.Equal(t, current[partition][0], replicas[0])
+			assert.NotEqual(t, replicas[0], replicas[1])
+			for _, r := range replicas {
+				load[r]++
+			}
+		}
+		assert.Equal(t, map[int32]int{1: 2, 2: 2, 3: 2}, load)
+	})
+
+	t.Run("Spread over added brokers", func(t *testing.T) {
+		current := map[int32][]int32{0: {1}, 1: {1}, 2: {1}, 3: {1}}
+
+		proposal, err := SpreadReplicas(current, []int32{1, 2}, 2)
+
+		assert.NoError(t, err)
+		for _, replicas := range proposal {
+			assert.Equal(t, []int32{1, 2}, replicas)
+		}
+	})
+
+	t.Run("Lower replication factor keeps the preferred leader", func(t *testing.T) {
+		current := map[int32][]int32{0: {1, 2, 3}, 1: {2, 3, 1}, 2: {3, 1, 2}}
+
+		proposal, err := SpreadReplicas(current, []int32{1, 2, 3}, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[int32][]int32{0: {1}, 1: {2}, 2: {3}}, proposal)
+	})
+
+	t.Run("Move replicas away from removed brokers", func(t *testing.T) {
+		current := map[int32][]int32{0: {1, 4}, 1: {4, 2}}
+
+		proposal, err := SpreadReplicas(current, []int32{1, 2, 3}, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int32{1, 3}, proposal[0])
+		assert.Equal(t, int32(2), proposal[1][0])
+		assert.NotContains(t, proposal[1], int32(4))
+		assert.Len(t, proposal[1], 2)
+	})
+
+	t.Run("Replication factor cannot exceed brokers", func(t *testing.T) {
+		_, err := SpreadReplicas(map[int32][]int32{0: {1}}, []int32{1, 2}, 3)
+
+		assert.EqualError(t, err, "replication factor 3 exceeds the 2 available brokers")
+	})
+
+	t.Run("Replication factor must be positive", func(t *testing.T) {
+		_, err := SpreadReplicas(map[int32][]int32{0: {1}}, []int32{1, 2}, 0)
+
+		assert.EqualError(t, err, "replication factor must be at least 1")
+	})
+}
+
+func TestReassignPartitions(t *testing.T) {
+	t.Run("Reassign partitions and list reassignments", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		brokers := ka.ListBrokers().(BrokerListingStartedMsg)
+		var brokerId int32
+		select {
+		case b := <-brokers.Brokers:
+			assert.Len(t, b, 1)
+			brokerId = b[0].ID
+		case err := <-brokers.Err:
+			t.Fatal("Unable to list brokers", err)
+		}
+
+		// when
+		rsm := ka.ReassignPartitions(topic, map[int32][]int32{
+			0: {brokerId},
+			1: {brokerId},
+		}).(PartitionReassignmentStartedMsg)
+
+		// then
+		switch msg := rsm.AwaitCompletion().(type) {
+		case PartitionsReassignedMsg:
+		case PartitionReassignmentErrMsg:
+			t.Fatal("Unable to reassign partitions", msg.Err)
+		}
+
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			lsm := ka.ListPartitionReassignments(topic).(PartitionReassignmentsListingStartedMsg)
+			select {
+			case reassignments := <-lsm.Reassignments:
+				// moving replicas onto the brokers they are on completes immediately
+				assert.Empty(c, reassignments)
+			case err := <-lsm.Err:
+				c.Errorf("Unable to list reassignments: %v", err)
+			}
+		}, 5*time.Second, 100*time.Millisecond)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Replicas of all partitions are required", func(t *testing.T) {
+		rsm := ka.ReassignPartitions("topic", map[int32][]int32{
+			1: {1},
+		}).(PartitionReassignmentStartedMsg)
+
+		assert.Equal(t,
+			PartitionReassignmentErrMsg{fmt.Errorf("missing replicas of partition 0")},
+			rsm.AwaitCompletion(),
+		)
+	})
+}
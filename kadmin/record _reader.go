@@ -2,33 +2,31 @@ package kadmin
 
 import (
 	"context"
-	"ktea/serdes"
-	"strings"
+	"fmt"
+	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/IBM/sarama"
 	tea "github.com/charmbracelet/bubbletea"
+	"ktea/config"
+	"ktea/serdes"
 )
 
 type FilterType string
 
-func (filterDetails *Filter) Filter(value string) bool {
-	switch filterDetails.KeyFilter {
-	case ContainsFilterType:
-		return strings.Contains(value, filterDetails.KeySearchTerm)
-	case StartsWithFilterType:
-		return strings.HasPrefix(value, filterDetails.KeySearchTerm)
-	default:
-		return true
-	}
-}
-
 const (
-	ContainsFilterType   FilterType = "contains"
-	StartsWithFilterType FilterType = "starts with"
-	NoFilterType         FilterType = "none"
+	ContainsFilterType    FilterType = "contains"
+	NotContainsFilterType FilterType = "not contains"
+	StartsWithFilterType  FilterType = "starts with"
+	EqualsFilterType      FilterType = "equals"
+	RegexFilterType       FilterType = "regex"
+	// JsonPathFilterType compares a field of a JSON payload, e.g. $.order.status == "FAILED"
+	JsonPathFilterType FilterType = "json path"
+	PresentFilterType  FilterType = "present"
+	NoFilterType       FilterType = "none"
 )
 
 type StartPoint int
@@ -36,6 +34,9 @@ type StartPoint int
 const (
 	Beginning  StartPoint = 0
 	MostRecent StartPoint = 1
+	Timestamp  StartPoint = 2
+	// SpecificOffset reads each partition from the offsets in ReadDetails.PartitionOffsets
+	SpecificOffset StartPoint = 3
 )
 
 type RecordReader interface {
@@ -53,14 +54,37 @@ type Filter struct {
 	KeySearchTerm   string
 	ValueFilter     FilterType
 	ValueSearchTerm string
+	// HeaderFilter matches records having a header with HeaderKey, of which the value
+	// matches HeaderSearchTerm unless the PresentFilterType is used.
+	HeaderFilter     FilterType
+	HeaderKey        string
+	HeaderSearchTerm string
 }
 
 type ReadDetails struct {
 	Topic      *Topic
 	Partitions []int
 	StartPoint StartPoint
-	Limit      int
-	Filter     *Filter
+	// StartTimestamp is the moment to start reading from when StartPoint is Timestamp.
+	StartTimestamp time.Time
+	// EndTimestamp optionally bounds the read window when StartPoint is Timestamp,
+	// only records produced before it are read.
+	EndTimestamp *time.Time
+	// PartitionOffsets holds the offsets to read from, by partition, when StartPoint is SpecificOffset.
+	// Only the partitions present are read.
+	PartitionOffsets map[int]PartitionOffset
+	Limit            int
+	Filter           *Filter
+	// Follow keeps on reading records as they are produced, like tail -f,
+	// until the reading is cancelled. Limit and end offsets are ignored.
+	Follow bool
+}
+
+// PartitionOffset is an explicit offset range of a partition to read.
+type PartitionOffset struct {
+	Start int64
+	// End optionally bounds the range (inclusive), when nil reading continues up until the most recent record.
+	End *int64
 }
 
 type Header struct {
@@ -69,8 +93,11 @@ type Header struct {
 }
 
 type ConsumerRecord struct {
-	Key       string
-	Value     string
+	Key   string
+	Value string
+	// RawKey and RawValue are the bytes as stored in the topic, before deserialization
+	RawKey    []byte
+	RawValue  []byte
 	Partition int64
 	Offset    int64
 	Headers   []Header
@@ -81,6 +108,10 @@ type offsets struct {
 	oldest int64
 	// most recent available, unused, offset
 	firstAvailable int64
+	// first offset at or after ReadDetails.StartTimestamp, -1 when there is none
+	startTimestamp int64
+	// first offset at or after ReadDetails.EndTimestamp, -1 when there is none
+	endTimestamp int64
 }
 
 func (o *offsets) newest() int64 {
@@ -98,11 +129,28 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 		CancelFunc:     cancelFunc,
 	}
 
+	matcher, err := newRecordMatcher(rd.Filter)
+	if err != nil {
+		cancelFunc()
+		return KAdminErrorMsg{err}
+	}
+
+	keyFormat, valueFormat := config.FormatsOf(ka.topicFormats, rd.Topic.Name)
+	keyDeserializer, err := ka.deserializers.Of(keyFormat)
+	if err != nil {
+		cancelFunc()
+		return KAdminErrorMsg{err}
+	}
+	valueDeserializer, err := ka.deserializers.Of(valueFormat)
+	if err != nil {
+		cancelFunc()
+		return KAdminErrorMsg{err}
+	}
+
 	client, err := sarama.NewConsumerFromClient(ka.client)
 	if err != nil {
-		close(startedMsg.ConsumerRecord)
-		close(startedMsg.Err)
-		return startedMsg
+		cancelFunc()
+		return KAdminErrorMsg{err}
 	}
 
 	var (
@@ -110,69 +158,74 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 		closeOnce  sync.Once
 		wg         sync.WaitGroup
 		offsets    map[int]offsets
-		ok         bool
 		partitions []int
 	)
 
 	partitions = ka.determineReadPartitions(rd)
 
-	offsets, ok = ka.fetchOffsets(partitions, rd, startedMsg)
-	if !ok {
-		close(startedMsg.Err)
+	offsets, err = ka.fetchOffsets(partitions, rd)
+	if err != nil {
 		cancelFunc()
-		return startedMsg
+		return KAdminErrorMsg{err}
 	}
 
-	wg.Add(len(partitions))
+	if rd.StartPoint == SpecificOffset {
+		if err := validatePartitionOffsets(rd.PartitionOffsets, offsets); err != nil {
+			cancelFunc()
+			return KAdminErrorMsg{err}
+		}
+	}
 
 	var atLeastOnePartitionReadable bool
 	for _, partition := range partitions {
-		if offsets[partition].firstAvailable != offsets[partition].oldest {
-			atLeastOnePartitionReadable = true
-			go func(partition int) {
-				defer wg.Done()
-
-				readingOffsets := ka.determineReadingOffsets(rd, offsets[partition])
-				consumer, err := client.ConsumePartition(
-					rd.Topic.Name,
-					int32(partition),
-					readingOffsets.start,
-				)
-				if err != nil {
-					startedMsg.Err <- err
-					cancelFunc()
-					return
-				}
-				defer consumer.Close()
+		partitionOffsets := ka.determineReadingOffsets(rd, partition, offsets[partition])
+		if partitionOffsets.start > partitionOffsets.end && !rd.Follow {
+			continue
+		}
+		atLeastOnePartitionReadable = true
+		wg.Add(1)
+		go func(partition int, readingOffsets readingOffsets) {
+			defer wg.Done()
 
-				msgChan := consumer.Messages()
+			consumer, err := client.ConsumePartition(
+				rd.Topic.Name,
+				int32(partition),
+				readingOffsets.start,
+			)
+			if err != nil {
+				startedMsg.Err <- err
+				cancelFunc()
+				return
+			}
+			defer consumer.Close()
 
-				for {
-					select {
-					case err := <-consumer.Errors():
-						startedMsg.Err <- err
-						return
-					case <-ctx.Done():
-						return
-					case msg := <-msgChan:
-						var headers []Header
-						for _, h := range msg.Headers {
-							headers = append(headers, Header{
-								string(h.Key),
-								string(h.Value),
-							})
-						}
+			msgChan := consumer.Messages()
 
-						key := string(msg.Key)
-						value := ka.deserialize(err, msg)
+			for {
+				select {
+				case err := <-consumer.Errors():
+					startedMsg.Err <- err
+					return
+				case <-ctx.Done():
+					return
+				case msg := <-msgChan:
+					var headers []Header
+					for _, h := range msg.Headers {
+						headers = append(headers, Header{
+							string(h.Key),
+							string(h.Value),
+						})
+					}
 
-						if !ka.matchesFilter(key, value, rd.Filter) {
-							continue
-						}
+					key := deserialize(keyDeserializer, msg.Key)
+					value := deserialize(valueDeserializer, msg.Value)
 
+					if matcher.matches(key, value, headers) {
 						consumerRecord := ConsumerRecord{
 							Key:       key,
 							Value:     value,
+							RawKey:    msg.Key,
+							RawValue:  msg.Value,
 							Partition: int64(msg.Partition),
 							Offset:    msg.Offset,
 							Headers:   headers,
@@ -181,7 +234,7 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 
 						var shouldClose bool
 
-						if msgCount.Add(1) >= int64(rd.Limit) {
+						if msgCount.Add(1) >= int64(rd.Limit) && !rd.Follow {
 							shouldClose = true
 						}
 
@@ -195,14 +248,16 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 							cancelFunc() // Cancel the context to stop other goroutines
 							return
 						}
+					}
 
-						if msg.Offset == readingOffsets.end {
-							return
-						}
+					// checked regardless of the filter outcome, otherwise reading
+					// never ends when the last record of the window is filtered out
+					if msg.Offset >= readingOffsets.end && !rd.Follow {
+						return
 					}
 				}
-			}(partition)
-		}
+			}
+		}(partition, partitionOffsets)
 	}
 
 	go func() {
@@ -220,38 +275,53 @@ func (ka *SaramaKafkaAdmin) ReadRecords(ctx context.Context, rd ReadDetails) tea
 	}
 }
 
-func (ka *SaramaKafkaAdmin) matchesFilter(key, value string, filterDetails *Filter) bool {
-	if filterDetails == nil {
-		return true
-	}
-
-	if filterDetails.KeyFilter != NoFilterType {
-		return filterDetails.Filter(key)
-	}
-
-	if filterDetails.ValueSearchTerm != "" && !strings.Contains(value, filterDetails.ValueSearchTerm) {
-		return false
-	}
-
-	return true
-}
-
-func (ka *SaramaKafkaAdmin) deserialize(
-	err error,
-	msg *sarama.ConsumerMessage,
-) string {
-	deserializer := serdes.NewAvroDeserializer(ka.sra)
-	var payload string
-	payload, err = deserializer.Deserialize(msg.Value)
+// deserialize decodes a record key or value, a failure is shown in place of the data.
+func deserialize(deserializer serdes.Deserializer, data []byte) string {
+	payload, err := deserializer.Deserialize(data)
 	if err != nil {
 		payload = err.Error()
 	}
 	return payload
 }
 
+// validatePartitionOffsets makes sure the requested offsets fall within the watermarks of their partition.
+func validatePartitionOffsets(partitionOffsets map[int]PartitionOffset, offsets map[int]offsets) error {
+	for _, partition := range slices.Sorted(maps.Keys(partitionOffsets)) {
+		po := partitionOffsets[partition]
+		o := offsets[partition]
+		if o.firstAvailable == o.oldest {
+			return fmt.Errorf("partition %d does not contain any records", partition)
+		}
+		if po.Start < o.oldest || po.Start > o.newest() {
+			return fmt.Errorf(
+				"start offset %d of partition %d is out of range, available offsets are %d to %d",
+				po.Start, partition, o.oldest, o.newest(),
+			)
+		}
+		if po.End == nil {
+			continue
+		}
+		if *po.End < po.Start {
+			return fmt.Errorf(
+				"end offset %d of partition %d is before its start offset %d",
+				*po.End, partition, po.Start,
+			)
+		}
+		if *po.End > o.newest() {
+			return fmt.Errorf(
+				"end offset %d of partition %d is out of range, available offsets are %d to %d",
+				*po.End, partition, o.oldest, o.newest(),
+			)
+		}
+	}
+	return nil
+}
+
 func (ka *SaramaKafkaAdmin) determineReadPartitions(rd ReadDetails) []int {
 	var partitions []int
-	if len(rd.Partitions) == 0 {
+	if rd.StartPoint == SpecificOffset {
+		partitions = slices.Sorted(maps.Keys(rd.PartitionOffsets))
+	} else if len(rd.Partitions) == 0 {
 		partitions = make([]int, rd.Topic.Partitions)
 		for i := range partitions {
 			partitions[i] = i
@@ -269,11 +339,14 @@ type readingOffsets struct {
 
 func (ka *SaramaKafkaAdmin) determineReadingOffsets(
 	rd ReadDetails,
+	partition int,
 	offsets offsets,
 ) readingOffsets {
 	var startOffset int64
 	var endOffset int64
 	numberOfRecordsPerPart := int64(float64(int64(rd.Limit)) / float64(rd.Topic.Partitions))
+	// read at least one record per partition, the overall limit is enforced while consuming
+	numberOfRecordsPerPart = max(numberOfRecordsPerPart, 1)
 	if rd.StartPoint == Beginning {
 		startOffset, endOffset = ka.determineOffsetsFromBeginning(
 			startOffset,
@@ -281,6 +354,10 @@ func (ka *SaramaKafkaAdmin) determineReadingOffsets(
 			numberOfRecordsPerPart,
 			endOffset,
 		)
+	} else if rd.StartPoint == Timestamp {
+		startOffset, endOffset = ka.determineOffsetsFromTimestamp(offsets)
+	} else if rd.StartPoint == SpecificOffset {
+		startOffset, endOffset = ka.determineSpecificOffsets(rd.PartitionOffsets[partition], offsets)
 	} else {
 		startOffset, endOffset = ka.determineMostRecentOffsets(
 			startOffset,
@@ -309,6 +386,38 @@ func (ka *SaramaKafkaAdmin) determineMostRecentOffsets(
 	return startOffset, endOffset
 }
 
+// determineOffsetsFromTimestamp reads from the first record at or after the start timestamp
+// up until the last record before the end timestamp, or the most recent one when there is none.
+// The returned start offset exceeds the end offset when no records fall within that window.
+func (ka *SaramaKafkaAdmin) determineOffsetsFromTimestamp(offsets offsets) (int64, int64) {
+	startOffset := offsets.startTimestamp
+	if startOffset < 0 || startOffset > offsets.newest() {
+		return offsets.firstAvailable, offsets.newest()
+	}
+	if startOffset < offsets.oldest {
+		startOffset = offsets.oldest
+	}
+
+	endOffset := offsets.newest()
+	if offsets.endTimestamp >= 0 && offsets.endTimestamp-1 < endOffset {
+		endOffset = offsets.endTimestamp - 1
+	}
+	return startOffset, endOffset
+}
+
+// determineSpecificOffsets reads the explicitly requested range, up until the most recent record
+// when no end offset has been requested.
+func (ka *SaramaKafkaAdmin) determineSpecificOffsets(
+	partitionOffset PartitionOffset,
+	offsets offsets,
+) (int64, int64) {
+	endOffset := offsets.newest()
+	if partitionOffset.End != nil && *partitionOffset.End < endOffset {
+		endOffset = *partitionOffset.End
+	}
+	return partitionOffset.Start, endOffset
+}
+
 func (ka *SaramaKafkaAdmin) determineOffsetsFromBeginning(
 	startOffset int64,
 	offsets offsets,
@@ -327,8 +436,7 @@ func (ka *SaramaKafkaAdmin) determineOffsetsFromBeginning(
 func (ka *SaramaKafkaAdmin) fetchOffsets(
 	partitions []int,
 	rd ReadDetails,
-	rsm ReadingStartedMsg,
-) (map[int]offsets, bool) {
+) (map[int]offsets, error) {
 	offsetsByPartition := make(map[int]offsets)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -359,10 +467,37 @@ func (ka *SaramaKafkaAdmin) fetchOffsets(
 				return
 			}
 
+			startTimestampOffset, endTimestampOffset := int64(-1), int64(-1)
+			if rd.StartPoint == Timestamp {
+				startTimestampOffset, err = ka.client.GetOffset(
+					rd.Topic.Name,
+					int32(partition),
+					rd.StartTimestamp.UnixMilli(),
+				)
+				if err != nil {
+					errorsChan <- err
+					return
+				}
+
+				if rd.EndTimestamp != nil {
+					endTimestampOffset, err = ka.client.GetOffset(
+						rd.Topic.Name,
+						int32(partition),
+						rd.EndTimestamp.UnixMilli(),
+					)
+					if err != nil {
+						errorsChan <- err
+						return
+					}
+				}
+			}
+
 			mu.Lock()
 			offsetsByPartition[partition] = offsets{
-				oldestOffset,
-				firstAvailableOffset,
+				oldest:         oldestOffset,
+				firstAvailable: firstAvailableOffset,
+				startTimestamp: startTimestampOffset,
+				endTimestamp:   endTimestampOffset,
 			}
 			mu.Unlock()
 		}(partition)
@@ -372,11 +507,8 @@ func (ka *SaramaKafkaAdmin) fetchOffsets(
 
 	select {
 	case err := <-errorsChan:
-		rsm.Err <- err
-		close(rsm.ConsumerRecord)
-		close(rsm.Err)
-		return nil, false
+		return nil, err
 	default:
-		return offsetsByPartition, true
+		return offsetsByPartition, nil
 	}
 }
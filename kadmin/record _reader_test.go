@@ -30,8 +30,8 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
-						Value: "{\"id\":\"123\"}",
+						Key:   []byte(strconv.Itoa(i)),
+						Value: []byte("{\"id\":\"123\"}"),
 					})
 
 					select {
@@ -45,7 +45,7 @@ func TestReadRecords(t *testing.T) {
 
 			// then
 			rsm := ka.ReadRecords(context.Background(), ReadDetails{
-				Topic:      &Topic{topic, 1, 1, 1},
+				Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 				Partitions: []int{},
 				StartPoint: Beginning,
 				Limit:      50,
@@ -95,9 +95,9 @@ func TestReadRecords(t *testing.T) {
 					partition := i % 4
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic:     topic,
-						Key:       strconv.Itoa(i),
+						Key:       []byte(strconv.Itoa(i)),
 						Partition: &partition,
-						Value:     "{\"id\":\"123\"}",
+						Value:     []byte("{\"id\":\"123\"}"),
 					})
 
 					select {
@@ -111,7 +111,7 @@ func TestReadRecords(t *testing.T) {
 
 			// then
 			rsm := ka.ReadRecords(context.Background(), ReadDetails{
-				Topic:      &Topic{topic, 4, 1, 1},
+				Topic:      &Topic{Name: topic, Partitions: 4, Replicas: 1, Isr: 1},
 				Partitions: []int{},
 				StartPoint: Beginning,
 				Limit:      40,
@@ -161,8 +161,8 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
-						Value: "{\"id\":\"123\"}",
+						Key:   []byte(strconv.Itoa(i)),
+						Value: []byte("{\"id\":\"123\"}"),
 					})
 
 					select {
@@ -176,7 +176,7 @@ func TestReadRecords(t *testing.T) {
 
 			// then
 			rsm := ka.ReadRecords(context.Background(), ReadDetails{
-				Topic:      &Topic{topic, 1, 1, 1},
+				Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 				Partitions: []int{},
 				StartPoint: MostRecent,
 				Limit:      50,
@@ -223,8 +223,8 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
-						Value: "{\"id\":\"123\"}",
+						Key:   []byte(strconv.Itoa(i)),
+						Value: []byte("{\"id\":\"123\"}"),
 					})
 
 					select {
@@ -238,7 +238,7 @@ func TestReadRecords(t *testing.T) {
 
 			// then
 			rsm := ka.ReadRecords(context.Background(), ReadDetails{
-				Topic:      &Topic{topic, 1, 1, 1},
+				Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 				Partitions: []int{},
 				StartPoint: MostRecent,
 				Limit:      500,
@@ -288,8 +288,8 @@ func TestReadRecords(t *testing.T) {
 					for i := 0; i < 55; i++ {
 						psm := ka.PublishRecord(&ProducerRecord{
 							Topic: topic,
-							Key:   strconv.Itoa(i),
-							Value: "{\"id\":\"3\"}",
+							Key:   []byte(strconv.Itoa(i)),
+							Value: []byte("{\"id\":\"3\"}"),
 						})
 
 						select {
@@ -303,7 +303,7 @@ func TestReadRecords(t *testing.T) {
 
 				// then
 				rsm := ka.ReadRecords(context.Background(), ReadDetails{
-					Topic:      &Topic{topic, 1, 1, 1},
+					Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 					Partitions: []int{},
 					StartPoint: MostRecent,
 					Limit:      55,
@@ -358,8 +358,8 @@ func TestReadRecords(t *testing.T) {
 				for i := 0; i < 55; i++ {
 					psm := ka.PublishRecord(&ProducerRecord{
 						Topic: topic,
-						Key:   strconv.Itoa(i),
-						Value: "{\"id\":\"3\"}",
+						Key:   []byte(strconv.Itoa(i)),
+						Value: []byte("{\"id\":\"3\"}"),
 					})
 
 					select {
@@ -373,7 +373,7 @@ func TestReadRecords(t *testing.T) {
 
 			// then
 			rsm := ka.ReadRecords(context.Background(), ReadDetails{
-				Topic:      &Topic{topic, 1, 1, 1},
+				Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 				Partitions: []int{},
 				StartPoint: MostRecent,
 				Limit:      55,
@@ -407,6 +407,80 @@ func TestReadRecords(t *testing.T) {
 			ka.DeleteTopic(topic)
 		})
 
+		t.Run("header equals", func(t *testing.T) {
+			topic := topicName()
+			// given
+			msg := ka.CreateTopic(TopicCreationDetails{
+				Name:              topic,
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+			}).(TopicCreationStartedMsg)
+
+			switch msg.AwaitCompletion().(type) {
+			case TopicCreatedMsg:
+			case TopicCreationErrMsg:
+				t.Fatal("Unable to create topic", msg.Err)
+			}
+
+			// when
+			assert.EventuallyWithT(t, func(c *assert.CollectT) {
+				for i := 0; i < 10; i++ {
+					eventType := "OrderPlaced"
+					if i%2 == 0 {
+						eventType = "OrderFailed"
+					}
+					psm := ka.PublishRecord(&ProducerRecord{
+						Topic:   topic,
+						Key:     []byte(strconv.Itoa(i)),
+						Value:   []byte("{\"id\":\"3\"}"),
+						Headers: []Header{{"eventType", eventType}},
+					})
+
+					select {
+					case err := <-psm.Err:
+						t.Fatal(c, "Unable to publish", err)
+					case p := <-psm.Published:
+						assert.True(c, p)
+					}
+				}
+			}, 10*time.Second, 10*time.Millisecond)
+
+			// then
+			rsm := ka.ReadRecords(context.Background(), ReadDetails{
+				Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
+				Partitions: []int{},
+				StartPoint: Beginning,
+				Limit:      10,
+				Filter: &Filter{
+					KeyFilter:        NoFilterType,
+					HeaderFilter:     EqualsFilterType,
+					HeaderKey:        "eventType",
+					HeaderSearchTerm: "OrderFailed",
+				},
+			}).(ReadingStartedMsg)
+
+			var receivedRecords []int
+			for {
+				select {
+				case r, ok := <-rsm.ConsumerRecord:
+					if !ok {
+						goto assertRecords
+					}
+					key, _ := strconv.Atoi(r.Key)
+					receivedRecords = append(receivedRecords, key)
+				case <-time.After(5 * time.Second):
+					rsm.CancelFunc()
+					goto assertRecords
+				}
+			}
+
+		assertRecords:
+			assert.Equal(t, []int{0, 2, 4, 6, 8}, receivedRecords)
+
+			// clean up
+			ka.DeleteTopic(topic)
+		})
+
 	})
 }
 
@@ -419,10 +493,12 @@ type determineStartingOffsetTest struct {
 	name        string
 	want        want
 	readDetails ReadDetails
+	partition   int
 	offsets     offsets
 }
 
 func TestDetermineStartingOffset(t *testing.T) {
+	endOffset := int64(150)
 	var tests = []determineStartingOffsetTest{
 		{
 			name: "beginning one partition enough records available",
@@ -561,12 +637,140 @@ func TestDetermineStartingOffset(t *testing.T) {
 				end:   290,
 			},
 		},
+		{
+			name: "timestamp without end reads up until the most recent record",
+			readDetails: ReadDetails{
+				Topic: &Topic{
+					Name:       "test-topic",
+					Partitions: 1,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Partitions: []int{0},
+				StartPoint: Timestamp,
+				Limit:      50,
+			},
+			offsets: offsets{
+				oldest:         1,
+				firstAvailable: 291,
+				startTimestamp: 100,
+				endTimestamp:   -1,
+			},
+
+			want: want{
+				start: 100,
+				end:   290,
+			},
+		},
+		{
+			name: "timestamp with end reads up until the end",
+			readDetails: ReadDetails{
+				Topic: &Topic{
+					Name:       "test-topic",
+					Partitions: 1,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Partitions: []int{0},
+				StartPoint: Timestamp,
+				Limit:      50,
+			},
+			offsets: offsets{
+				oldest:         1,
+				firstAvailable: 291,
+				startTimestamp: 100,
+				endTimestamp:   150,
+			},
+
+			want: want{
+				start: 100,
+				end:   149,
+			},
+		},
+		{
+			name: "timestamp after the most recent record reads nothing",
+			readDetails: ReadDetails{
+				Topic: &Topic{
+					Name:       "test-topic",
+					Partitions: 1,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Partitions: []int{0},
+				StartPoint: Timestamp,
+				Limit:      50,
+			},
+			offsets: offsets{
+				oldest:         1,
+				firstAvailable: 291,
+				startTimestamp: -1,
+				endTimestamp:   -1,
+			},
+
+			want: want{
+				start: 291,
+				end:   290,
+			},
+		},
+		{
+			name: "specific offset without end reads up until the most recent record",
+			readDetails: ReadDetails{
+				Topic: &Topic{
+					Name:       "test-topic",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				StartPoint: SpecificOffset,
+				PartitionOffsets: map[int]PartitionOffset{
+					7: {Start: 120},
+				},
+				Limit: 50,
+			},
+			partition: 7,
+			offsets: offsets{
+				oldest:         1,
+				firstAvailable: 291,
+			},
+
+			want: want{
+				start: 120,
+				end:   290,
+			},
+		},
+		{
+			name: "specific offset with end reads up until the end",
+			readDetails: ReadDetails{
+				Topic: &Topic{
+					Name:       "test-topic",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				StartPoint: SpecificOffset,
+				PartitionOffsets: map[int]PartitionOffset{
+					7: {Start: 120, End: &endOffset},
+				},
+				Limit: 50,
+			},
+			partition: 7,
+			offsets: offsets{
+				oldest:         1,
+				firstAvailable: 291,
+			},
+
+			want: want{
+				start: 120,
+				end:   150,
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			offset := ka.(*SaramaKafkaAdmin).determineReadingOffsets(
 				test.readDetails,
+				test.partition,
 				test.offsets,
 			)
 			assert.Equal(t, test.want.start, offset.start, "unexpected start")
@@ -574,3 +778,61 @@ func TestDetermineStartingOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePartitionOffsets(t *testing.T) {
+	available := map[int]offsets{
+		0: {oldest: 10, firstAvailable: 100},
+		1: {oldest: 0, firstAvailable: 0},
+	}
+	offset := func(o int64) *int64 {
+		return &o
+	}
+
+	t.Run("within watermarks", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			0: {Start: 10, End: offset(99)},
+		}, available)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("start before low watermark", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			0: {Start: 9},
+		}, available)
+
+		assert.EqualError(t, err, "start offset 9 of partition 0 is out of range, available offsets are 10 to 99")
+	})
+
+	t.Run("start after most recent record", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			0: {Start: 100},
+		}, available)
+
+		assert.EqualError(t, err, "start offset 100 of partition 0 is out of range, available offsets are 10 to 99")
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			0: {Start: 50, End: offset(40)},
+		}, available)
+
+		assert.EqualError(t, err, "end offset 40 of partition 0 is before its start offset 50")
+	})
+
+	t.Run("end after most recent record", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			0: {Start: 50, End: offset(100)},
+		}, available)
+
+		assert.EqualError(t, err, "end offset 100 of partition 0 is out of range, available offsets are 10 to 99")
+	})
+
+	t.Run("empty partition", func(t *testing.T) {
+		err := validatePartitionOffsets(map[int]PartitionOffset{
+			1: {Start: 0},
+		}, available)
+
+		assert.EqualError(t, err, "partition 1 does not contain any records")
+	})
+}
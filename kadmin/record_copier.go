@@ -0,0 +1,156 @@
+package kadmin
+
+import (
+	"context"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type CopyDetails struct {
+	// ReadDetails selects the records to copy, it has to be limited as following is not supported
+	ReadDetails ReadDetails
+	TargetTopic string
+}
+
+type CopyStartedMsg struct {
+	Progress   chan CopyProgressMsg
+	CancelFunc context.CancelFunc
+	// Done is closed once the copy ended and the publisher is no longer used
+	Done chan struct{}
+}
+
+// CopyProgressMsg reports the records read from the source topic and the records published
+// to the target topic so far. Records are published in batches while reading.
+type CopyProgressMsg struct {
+	Read      int
+	Published int
+	Failed    int
+	Failures  []RecordPublicationFailure
+	// Err is set when reading failed, which ends the copy
+	Err error
+}
+
+type CopyEndedMsg struct{}
+
+// AwaitProgress waits for the next progress report, returning
+// CopyEndedMsg once the copy has ended.
+func (c *CopyStartedMsg) AwaitProgress() tea.Msg {
+	progress, ok := <-c.Progress
+	if !ok {
+		return CopyEndedMsg{}
+	}
+	return progress
+}
+
+// CopyRecords reads the records from the source topic and publishes them unaltered, using their raw bytes,
+// to the target topic. The publisher can be connected to another cluster than the reader.
+// Records are published with their original key, headers and timestamp, the partition is determined
+// by the target topic's partitioner. At most bulkProgressInterval records are kept in memory.
+func CopyRecords(
+	ctx context.Context,
+	reader RecordReader,
+	publisher Publisher,
+	cd CopyDetails,
+) tea.Msg {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	msg := reader.ReadRecords(ctx, cd.ReadDetails)
+	readingStartedMsg, ok := msg.(ReadingStartedMsg)
+	if !ok {
+		// nothing to copy, or reading failed
+		cancelFunc()
+		return msg
+	}
+
+	progress := make(chan CopyProgressMsg)
+	done := make(chan struct{})
+	go doCopyRecords(ctx, readingStartedMsg, publisher, cd.TargetTopic, progress, done)
+
+	return CopyStartedMsg{
+		Progress:   progress,
+		CancelFunc: cancelFunc,
+		Done:       done,
+	}
+}
+
+func doCopyRecords(
+	ctx context.Context,
+	readingStartedMsg ReadingStartedMsg,
+	publisher Publisher,
+	targetTopic string,
+	progress chan CopyProgressMsg,
+	done chan struct{},
+) {
+	defer close(done)
+	defer close(progress)
+	defer readingStartedMsg.CancelFunc()
+
+	// send stops when the copy is cancelled, as nobody is waiting for progress anymore
+	send := func(report CopyProgressMsg) bool {
+		select {
+		case progress <- report:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	batch := make([]ProducerRecord, 0, bulkProgressInterval)
+	report := CopyProgressMsg{}
+	publishBatch := func() bool {
+		// failures are indexed within the batch
+		offset := report.Published + report.Failed
+		var published, failed int
+		var failures []RecordPublicationFailure
+		bulkStartedMsg := publisher.PublishRecords(ctx, batch)
+		for bulkProgress := range bulkStartedMsg.Progress {
+			published = bulkProgress.Published
+			failed = bulkProgress.Failed
+			for _, failure := range bulkProgress.Failures {
+				failure.Index += offset
+				failures = append(failures, failure)
+			}
+		}
+		batch = batch[:0]
+
+		report.Published += published
+		report.Failed += failed
+		report.Failures = failures
+		sent := send(report)
+		report.Failures = nil
+		return sent
+	}
+
+	for {
+		select {
+		case record, ok := <-readingStartedMsg.ConsumerRecord:
+			if !ok {
+				if len(batch) > 0 {
+					publishBatch()
+				} else {
+					send(report)
+				}
+				return
+			}
+			batch = append(batch, toCopiedRecord(record, targetTopic))
+			report.Read++
+			if len(batch) == bulkProgressInterval && !publishBatch() {
+				return
+			}
+		case err := <-readingStartedMsg.Err:
+			report.Err = err
+			send(report)
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func toCopiedRecord(record ConsumerRecord, targetTopic string) ProducerRecord {
+	return ProducerRecord{
+		Key:       record.RawKey,
+		Value:     record.RawValue,
+		Topic:     targetTopic,
+		Headers:   record.Headers,
+		Timestamp: record.Timestamp,
+	}
+}
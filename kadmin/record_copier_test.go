@@ -0,0 +1,186 @@
+package kadmin
+
+import (
+	"context"
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type fakeRecordReader struct {
+	records []ConsumerRecord
+	err     error
+	msg     tea.Msg
+}
+
+func (r *fakeRecordReader) ReadRecords(ctx context.Context, rd ReadDetails) tea.Msg {
+	if r.msg != nil {
+		return r.msg
+	}
+	records := make(chan ConsumerRecord, len(r.records))
+	errs := make(chan error, 1)
+	for _, record := range r.records {
+		records <- record
+	}
+	if r.err != nil {
+		errs <- r.err
+	} else {
+		close(records)
+	}
+	return ReadingStartedMsg{ConsumerRecord: records, Err: errs, CancelFunc: func() {}}
+}
+
+type fakeBulkPublisher struct {
+	published []ProducerRecord
+}
+
+func (p *fakeBulkPublisher) PublishRecord(record *ProducerRecord) PublicationStartedMsg {
+	return PublicationStartedMsg{}
+}
+
+// PublishRecords fails the last record of every batch.
+func (p *fakeBulkPublisher) PublishRecords(ctx context.Context, records []ProducerRecord) BulkPublicationStartedMsg {
+	p.published = append(p.published, records...)
+	progress := make(chan BulkPublicationProgressMsg, 1)
+	progress <- BulkPublicationProgressMsg{
+		Published: len(records) - 1,
+		Failed:    1,
+		Total:     len(records),
+		Failures:  []RecordPublicationFailure{{Index: len(records) - 1, Err: errors.New("failed")}},
+	}
+	close(progress)
+	return BulkPublicationStartedMsg{Progress: progress, Total: len(records)}
+}
+
+func awaitCopy(msg tea.Msg) []CopyProgressMsg {
+	startedMsg := msg.(CopyStartedMsg)
+	var reports []CopyProgressMsg
+	for {
+		switch msg := startedMsg.AwaitProgress().(type) {
+		case CopyProgressMsg:
+			reports = append(reports, msg)
+		case CopyEndedMsg:
+			return reports
+		}
+	}
+}
+
+func TestCopyRecords(t *testing.T) {
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("publishes the raw records to the target topic", func(t *testing.T) {
+		reader := &fakeRecordReader{records: []ConsumerRecord{
+			{
+				Key:       "decoded",
+				Value:     "decoded",
+				RawKey:    []byte{0x00, 0x01},
+				RawValue:  []byte{0x00, 0x02},
+				Partition: 3,
+				Headers:   []Header{{"h1", "v1"}},
+				Timestamp: timestamp,
+			},
+			{RawKey: []byte("key-2"), RawValue: []byte("value-2"), Timestamp: timestamp},
+		}}
+		publisher := &fakeBulkPublisher{}
+
+		reports := awaitCopy(CopyRecords(context.Background(), reader, publisher, CopyDetails{
+			ReadDetails: ReadDetails{Topic: &Topic{Name: "source"}, Limit: 2},
+			TargetTopic: "target",
+		}))
+
+		assert.Equal(t, []ProducerRecord{
+			{
+				Key:       []byte{0x00, 0x01},
+				Value:     []byte{0x00, 0x02},
+				Topic:     "target",
+				Headers:   []Header{{"h1", "v1"}},
+				Timestamp: timestamp,
+			},
+			{
+				Key:       []byte("key-2"),
+				Value:     []byte("value-2"),
+				Topic:     "target",
+				Timestamp: timestamp,
+			},
+		}, publisher.published)
+		assert.Equal(t, []CopyProgressMsg{
+			{
+				Read:      2,
+				Published: 1,
+				Failed:    1,
+				Failures:  []RecordPublicationFailure{{Index: 1, Err: errors.New("failed")}},
+			},
+		}, reports)
+	})
+
+	t.Run("publishes in batches while reading", func(t *testing.T) {
+		var records []ConsumerRecord
+		for i := 0; i < 250; i++ {
+			records = append(records, ConsumerRecord{RawKey: []byte(strconv.Itoa(i))})
+		}
+		reader := &fakeRecordReader{records: records}
+		publisher := &fakeBulkPublisher{}
+
+		reports := awaitCopy(CopyRecords(context.Background(), reader, publisher, CopyDetails{
+			ReadDetails: ReadDetails{Topic: &Topic{Name: "source"}, Limit: 250},
+			TargetTopic: "target",
+		}))
+
+		assert.Len(t, publisher.published, 250)
+		assert.Equal(t, []byte("249"), publisher.published[249].Key)
+		assert.Len(t, reports, 3)
+		assert.Equal(t, 100, reports[0].Read)
+		assert.Equal(t, 199, reports[1].Failures[0].Index)
+		assert.Equal(t, CopyProgressMsg{
+			Read:      250,
+			Published: 247,
+			Failed:    3,
+			Failures:  []RecordPublicationFailure{{Index: 249, Err: errors.New("failed")}},
+		}, reports[2])
+	})
+
+	t.Run("keeps null keys and tombstones", func(t *testing.T) {
+		reader := &fakeRecordReader{records: []ConsumerRecord{
+			{RawValue: []byte("value-1"), Timestamp: timestamp},
+			{RawKey: []byte("key-2"), Timestamp: timestamp},
+		}}
+		publisher := &fakeBulkPublisher{}
+
+		awaitCopy(CopyRecords(context.Background(), reader, publisher, CopyDetails{
+			ReadDetails: ReadDetails{Topic: &Topic{Name: "source"}, Limit: 2},
+			TargetTopic: "target",
+		}))
+
+		assert.Len(t, publisher.published, 2)
+		assert.Nil(t, publisher.published[0].Key)
+		assert.Equal(t, []byte("value-1"), publisher.published[0].Value)
+		assert.Equal(t, []byte("key-2"), publisher.published[1].Key)
+		assert.Nil(t, publisher.published[1].Value)
+	})
+
+	t.Run("reading failure ends the copy", func(t *testing.T) {
+		reader := &fakeRecordReader{
+			records: []ConsumerRecord{{RawKey: []byte("key-1")}},
+			err:     errors.New("broker not available"),
+		}
+		publisher := &fakeBulkPublisher{}
+
+		reports := awaitCopy(CopyRecords(context.Background(), reader, publisher, CopyDetails{
+			TargetTopic: "target",
+		}))
+
+		assert.Nil(t, publisher.published)
+		assert.EqualError(t, reports[len(reports)-1].Err, "broker not available")
+	})
+
+	t.Run("empty source topic", func(t *testing.T) {
+		reader := &fakeRecordReader{msg: EmptyTopicMsg{}}
+
+		msg := CopyRecords(context.Background(), reader, &fakeBulkPublisher{}, CopyDetails{})
+
+		assert.Equal(t, EmptyTopicMsg{}, msg)
+	})
+}
@@ -0,0 +1,59 @@
+package kadmin
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type RecordDeleter interface {
+	DeleteRecords(rdd RecordDeletionDetails) tea.Msg
+}
+
+type RecordDeletionDetails struct {
+	Topic string
+	// Offsets holds per partition the offset before which all records are deleted,
+	// the offset becomes the new low watermark of the partition.
+	Offsets map[int32]int64
+}
+
+type RecordDeletionStartedMsg struct {
+	Deleted chan bool
+	Err     chan error
+}
+
+type RecordsDeletedMsg struct {
+}
+
+type RecordDeletionErrMsg struct {
+	Err error
+}
+
+func (msg *RecordDeletionStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case <-msg.Deleted:
+		return RecordsDeletedMsg{}
+	case err := <-msg.Err:
+		return RecordDeletionErrMsg{Err: err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) DeleteRecords(rdd RecordDeletionDetails) tea.Msg {
+	deleted := make(chan bool)
+	err := make(chan error)
+
+	go ka.doDeleteRecords(rdd, deleted, err)
+
+	return RecordDeletionStartedMsg{
+		Deleted: deleted,
+		Err:     err,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doDeleteRecords(rdd RecordDeletionDetails, deleted chan bool, errChan chan error) {
+	maybeIntroduceLatency()
+	err := ka.admin.DeleteRecords(rdd.Topic, rdd.Offsets)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	deleted <- true
+}
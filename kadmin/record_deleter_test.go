@@ -0,0 +1,96 @@
+package kadmin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDeleteRecords(t *testing.T) {
+	t.Run("Delete records before offset", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		for _, partition := range []int{0, 1} {
+			for i := 0; i < 10; i++ {
+				psm := ka.PublishRecord(&ProducerRecord{
+					Key:       []byte("key"),
+					Value:     []byte("value"),
+					Topic:     topic,
+					Partition: &partition,
+				})
+				psm.AwaitCompletion()
+			}
+		}
+
+		// when
+		dsm := ka.DeleteRecords(RecordDeletionDetails{
+			Topic:   topic,
+			Offsets: map[int32]int64{0: 4, 1: 10},
+		}).(RecordDeletionStartedMsg)
+
+		// then
+		switch msg := dsm.AwaitCompletion().(type) {
+		case RecordsDeletedMsg:
+		case RecordDeletionErrMsg:
+			t.Fatal("Unable to delete records", msg.Err)
+		}
+
+		describeMsg := ka.DescribeTopic(topic).(TopicDescriptionStartedMsg)
+		select {
+		case partitions := <-describeMsg.Partitions:
+			assert.Equal(t, int64(4), partitions[0].LowWatermark)
+			assert.Equal(t, int64(6), partitions[0].MessageCount())
+			assert.Equal(t, int64(10), partitions[1].LowWatermark)
+			assert.Equal(t, int64(0), partitions[1].MessageCount())
+		case err := <-describeMsg.Err:
+			t.Fatal("Error while describing topic", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test timed out waiting for partitions")
+		}
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+
+	t.Run("Deleting beyond the high watermark fails", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     1,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		// when
+		dsm := ka.DeleteRecords(RecordDeletionDetails{
+			Topic:   topic,
+			Offsets: map[int32]int64{0: 5},
+		}).(RecordDeletionStartedMsg)
+
+		// then
+		assert.IsType(t, RecordDeletionErrMsg{}, dsm.AwaitCompletion())
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+}
@@ -0,0 +1,200 @@
+package kadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// termMatcher reports whether a key, value or header value matches a filter term.
+type termMatcher func(string) bool
+
+// recordMatcher matches consumed records against a Filter.
+// Its matchers are built once, before reading starts, so regular expressions
+// and JSON paths are not parsed again for every record.
+type recordMatcher struct {
+	key       termMatcher
+	value     termMatcher
+	header    termMatcher
+	headerKey string
+}
+
+func newRecordMatcher(filter *Filter) (*recordMatcher, error) {
+	m := &recordMatcher{}
+	if filter == nil {
+		return m, nil
+	}
+
+	var err error
+	if m.key, err = newTermMatcher(filter.KeyFilter, filter.KeySearchTerm); err != nil {
+		return nil, fmt.Errorf("invalid key filter: %w", err)
+	}
+	if m.value, err = newTermMatcher(filter.ValueFilter, filter.ValueSearchTerm); err != nil {
+		return nil, fmt.Errorf("invalid value filter: %w", err)
+	}
+
+	m.headerKey = filter.HeaderKey
+	if filter.HeaderFilter == PresentFilterType {
+		m.header = func(string) bool { return true }
+	} else if m.header, err = newTermMatcher(filter.HeaderFilter, filter.HeaderSearchTerm); err != nil {
+		return nil, fmt.Errorf("invalid header filter: %w", err)
+	}
+
+	return m, nil
+}
+
+func (m *recordMatcher) matches(key, value string, headers []Header) bool {
+	if m.key != nil && !m.key(key) {
+		return false
+	}
+	if m.value != nil && !m.value(value) {
+		return false
+	}
+	if m.header != nil {
+		return m.matchesHeaders(headers)
+	}
+	return true
+}
+
+// matchesHeaders reports whether one of the headers with the filtered key matches.
+func (m *recordMatcher) matchesHeaders(headers []Header) bool {
+	for _, header := range headers {
+		if header.Key == m.headerKey && m.header(header.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFilterTerm reports whether term can be used with filterType,
+// for example if it is a valid regular expression.
+func ValidateFilterTerm(filterType FilterType, term string) error {
+	_, err := newTermMatcher(filterType, term)
+	return err
+}
+
+func newTermMatcher(filterType FilterType, term string) (termMatcher, error) {
+	switch filterType {
+	case "", NoFilterType:
+		return nil, nil
+	case ContainsFilterType:
+		return func(s string) bool { return strings.Contains(s, term) }, nil
+	case NotContainsFilterType:
+		return func(s string) bool { return !strings.Contains(s, term) }, nil
+	case StartsWithFilterType:
+		return func(s string) bool { return strings.HasPrefix(s, term) }, nil
+	case EqualsFilterType:
+		return func(s string) bool { return s == term }, nil
+	case RegexFilterType:
+		regex, err := regexp.Compile(term)
+		if err != nil {
+			return nil, err
+		}
+		return regex.MatchString, nil
+	case JsonPathFilterType:
+		expr, err := parseJsonPathExpr(term)
+		if err != nil {
+			return nil, err
+		}
+		return expr.matches, nil
+	default:
+		return nil, fmt.Errorf("unknown filter type %q", filterType)
+	}
+}
+
+// jsonPathExpr is an equality check on a field of a JSON document, e.g. $.order.items[0].sku == "A-1".
+type jsonPathExpr struct {
+	// path holds field names (string) and array indexes (int)
+	path     []any
+	expected any
+}
+
+func parseJsonPathExpr(expr string) (*jsonPathExpr, error) {
+	pathPart, expectedPart, found := strings.Cut(expr, "==")
+	if !found {
+		return nil, fmt.Errorf("'%s' is not an expression like $.order.status == \"FAILED\"", expr)
+	}
+
+	path, err := parseJsonPath(strings.TrimSpace(pathPart))
+	if err != nil {
+		return nil, err
+	}
+
+	expectedPart = strings.TrimSpace(expectedPart)
+	var expected any
+	if err := json.Unmarshal([]byte(expectedPart), &expected); err != nil {
+		// unquoted text is compared as a string
+		expected = expectedPart
+	}
+
+	return &jsonPathExpr{path, expected}, nil
+}
+
+func parseJsonPath(path string) ([]any, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("path '%s' must start with $", path)
+	}
+
+	var segments []any
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("path '%s' contains an empty field name", path)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("path '%s' contains an unclosed [", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("path '%s' contains an invalid array index", path)
+			}
+			segments = append(segments, index)
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("path '%s' is not in the format $.field.nested[0]", path)
+		}
+	}
+	return segments, nil
+}
+
+func (e *jsonPathExpr) matches(payload string) bool {
+	var node any
+	if err := json.Unmarshal([]byte(payload), &node); err != nil {
+		return false
+	}
+
+	for _, segment := range e.path {
+		switch segment := segment.(type) {
+		case string:
+			object, ok := node.(map[string]any)
+			if !ok {
+				return false
+			}
+			if node, ok = object[segment]; !ok {
+				return false
+			}
+		case int:
+			array, ok := node.([]any)
+			if !ok || segment >= len(array) {
+				return false
+			}
+			node = array[segment]
+		}
+	}
+
+	return reflect.DeepEqual(node, e.expected)
+}
@@ -0,0 +1,115 @@
+package kadmin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecordMatcher(t *testing.T) {
+	t.Run("key and value filters are both applied", func(t *testing.T) {
+		matcher, err := newRecordMatcher(&Filter{
+			KeyFilter:       StartsWithFilterType,
+			KeySearchTerm:   "order-",
+			ValueFilter:     StartsWithFilterType,
+			ValueSearchTerm: "{",
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, matcher.matches("order-1", "{}", nil))
+		assert.False(t, matcher.matches("order-1", "[]", nil))
+		assert.False(t, matcher.matches("invoice-1", "{}", nil))
+	})
+
+	t.Run("no filter matches everything", func(t *testing.T) {
+		matcher, err := newRecordMatcher(nil)
+
+		assert.NoError(t, err)
+		assert.True(t, matcher.matches("", "", nil))
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		_, err := newRecordMatcher(&Filter{
+			ValueFilter:     RegexFilterType,
+			ValueSearchTerm: "order-(",
+		})
+
+		assert.EqualError(t, err, "invalid value filter: error parsing regexp: missing closing ): `order-(`")
+	})
+}
+
+func TestRecordMatcherHeaders(t *testing.T) {
+	headers := []Header{
+		{"traceId", "abc-123"},
+		{"tenant", "acme"},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"no header filter", Filter{HeaderFilter: NoFilterType}, true},
+		{"present", Filter{HeaderFilter: PresentFilterType, HeaderKey: "tenant"}, true},
+		{"not present", Filter{HeaderFilter: PresentFilterType, HeaderKey: "eventType"}, false},
+		{"equals", Filter{HeaderFilter: EqualsFilterType, HeaderKey: "tenant", HeaderSearchTerm: "acme"}, true},
+		{"not equals", Filter{HeaderFilter: EqualsFilterType, HeaderKey: "tenant", HeaderSearchTerm: "acm"}, false},
+		{"contains", Filter{HeaderFilter: ContainsFilterType, HeaderKey: "traceId", HeaderSearchTerm: "c-1"}, true},
+		{"starts with", Filter{HeaderFilter: StartsWithFilterType, HeaderKey: "traceId", HeaderSearchTerm: "abc"}, true},
+		{"value of other header", Filter{HeaderFilter: EqualsFilterType, HeaderKey: "traceId", HeaderSearchTerm: "acme"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matcher, err := newRecordMatcher(&test.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, matcher.matches("", "", headers))
+		})
+	}
+}
+
+func TestTermMatcher(t *testing.T) {
+	tests := []struct {
+		name       string
+		filterType FilterType
+		term       string
+		value      string
+		want       bool
+	}{
+		{"contains", ContainsFilterType, "der", "order", true},
+		{"not contains", NotContainsFilterType, "der", "order", false},
+		{"not contains absent term", NotContainsFilterType, "invoice", "order", true},
+		{"starts with", StartsWithFilterType, "ord", "order", true},
+		{"exact", EqualsFilterType, "order", "order", true},
+		{"exact partial", EqualsFilterType, "ord", "order", false},
+		{"regex", RegexFilterType, "^order-[0-9]+$", "order-12", true},
+		{"regex no match", RegexFilterType, "^order-[0-9]+$", "order-a", false},
+		{"json path string", JsonPathFilterType, `$.order.status == "FAILED"`, `{"order":{"status":"FAILED"}}`, true},
+		{"json path other value", JsonPathFilterType, `$.order.status == "FAILED"`, `{"order":{"status":"PAID"}}`, false},
+		{"json path unquoted string", JsonPathFilterType, `$.order.status == FAILED`, `{"order":{"status":"FAILED"}}`, true},
+		{"json path number", JsonPathFilterType, `$.order.amount == 10`, `{"order":{"amount":10.0}}`, true},
+		{"json path array index", JsonPathFilterType, `$.items[1].sku == "B"`, `{"items":[{"sku":"A"},{"sku":"B"}]}`, true},
+		{"json path missing field", JsonPathFilterType, `$.order.status == "FAILED"`, `{"order":{}}`, false},
+		{"json path on non json payload", JsonPathFilterType, `$.order.status == "FAILED"`, `order`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matcher, err := newTermMatcher(test.filterType, test.term)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, matcher(test.value))
+		})
+	}
+}
+
+func TestValidateFilterTerm(t *testing.T) {
+	assert.NoError(t, ValidateFilterTerm(NoFilterType, ""))
+	assert.NoError(t, ValidateFilterTerm(JsonPathFilterType, `$.a[0].b == true`))
+	assert.EqualError(t, ValidateFilterTerm(JsonPathFilterType, `$.status`),
+		`'$.status' is not an expression like $.order.status == "FAILED"`)
+	assert.EqualError(t, ValidateFilterTerm(JsonPathFilterType, `status == "FAILED"`),
+		"path 'status' must start with $")
+	assert.EqualError(t, ValidateFilterTerm(JsonPathFilterType, `$.items[x] == 1`),
+		"path '$.items[x]' contains an invalid array index")
+}
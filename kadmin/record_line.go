@@ -0,0 +1,90 @@
+package kadmin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RecordLine is a record in the JSON Lines format records are exported to and imported from.
+// Keys, values and header values are the raw bytes, base64 encoded, so that records of any
+// format are published unaltered when imported. Null keys and tombstones are kept as null.
+type RecordLine struct {
+	Key       []byte       `json:"key"`
+	Value     []byte       `json:"value"`
+	Headers   []HeaderLine `json:"headers"`
+	Partition int64        `json:"partition"`
+	Offset    int64        `json:"offset"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// HeaderLine is a header of a RecordLine, headers are kept in order as keys can repeat.
+type HeaderLine struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func NewRecordLine(record ConsumerRecord) RecordLine {
+	headers := make([]HeaderLine, 0, len(record.Headers))
+	for _, header := range record.Headers {
+		headers = append(headers, HeaderLine{header.Key, []byte(header.Value)})
+	}
+	return RecordLine{
+		Key:       record.RawKey,
+		Value:     record.RawValue,
+		Headers:   headers,
+		Partition: record.Partition,
+		Offset:    record.Offset,
+		Timestamp: record.Timestamp,
+	}
+}
+
+// ToProducerRecord creates the record to publish to topic, the original partition and
+// timestamp are only kept when asked for.
+func (l *RecordLine) ToProducerRecord(topic string, keepPartition bool, keepTimestamp bool) ProducerRecord {
+	headers := make([]Header, 0, len(l.Headers))
+	for _, header := range l.Headers {
+		headers = append(headers, Header{header.Key, string(header.Value)})
+	}
+
+	record := ProducerRecord{
+		Key:     l.Key,
+		Value:   l.Value,
+		Topic:   topic,
+		Headers: headers,
+	}
+	if keepPartition {
+		partition := int(l.Partition)
+		record.Partition = &partition
+	}
+	if keepTimestamp {
+		record.Timestamp = l.Timestamp
+	}
+	return record
+}
+
+// ReadRecordLines reads all records, blank lines are skipped.
+func ReadRecordLines(r io.Reader) ([]RecordLine, error) {
+	var lines []RecordLine
+	scanner := bufio.NewScanner(r)
+	// records can be a lot larger than the default maximum line length
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var line RecordLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("line %d is not a valid record: %w", lineNumber, err)
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
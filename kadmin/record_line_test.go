@@ -0,0 +1,86 @@
+package kadmin
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordLine(t *testing.T) {
+	timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("read exported records", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		for _, record := range []ConsumerRecord{
+			{Key: "key-1", Value: "value-1", RawKey: []byte("key-1"), RawValue: []byte{0x00, 0x01}, Partition: 1, Offset: 10, Timestamp: timestamp},
+			{Value: "value-2", RawValue: []byte("value-2"), Headers: []Header{{"h1", "v1"}, {"h1", "v2"}}, Partition: 2, Offset: 11, Timestamp: timestamp},
+		} {
+			assert.NoError(t, encoder.Encode(NewRecordLine(record)))
+		}
+		// blank lines are ignored
+		buf.WriteString("\n")
+
+		lines, err := ReadRecordLines(&buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []RecordLine{
+			{Key: []byte("key-1"), Value: []byte{0x00, 0x01}, Headers: []HeaderLine{}, Partition: 1, Offset: 10, Timestamp: timestamp},
+			{Value: []byte("value-2"), Headers: []HeaderLine{{"h1", []byte("v1")}, {"h1", []byte("v2")}}, Partition: 2, Offset: 11, Timestamp: timestamp},
+		}, lines)
+	})
+
+	t.Run("export raw bytes as base64", func(t *testing.T) {
+		line, err := json.Marshal(NewRecordLine(ConsumerRecord{
+			Key:       "decoded",
+			RawValue:  []byte("value"),
+			Headers:   []Header{{"h1", "v1"}},
+			Timestamp: timestamp,
+		}))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"key": null,
+			"value": "dmFsdWU=",
+			"headers": [{"key": "h1", "value": "djE="}],
+			"partition": 0,
+			"offset": 0,
+			"timestamp": "2025-01-02T03:04:05Z"
+		}`, string(line))
+	})
+
+	t.Run("read invalid record", func(t *testing.T) {
+		_, err := ReadRecordLines(strings.NewReader("{\"key\":\"a2V5LTE=\"}\n{\"key\":\"key-1\"}"))
+
+		assert.ErrorContains(t, err, "line 2 is not a valid record")
+	})
+
+	t.Run("to producer record", func(t *testing.T) {
+		line := RecordLine{
+			Key:       []byte("key-1"),
+			Headers:   []HeaderLine{{"h1", []byte("v1")}, {"h1", []byte("v2")}},
+			Partition: 3,
+			Timestamp: timestamp,
+		}
+
+		t.Run("without partition and timestamp", func(t *testing.T) {
+			record := line.ToProducerRecord("target", false, false)
+
+			assert.Equal(t, ProducerRecord{
+				Key:     []byte("key-1"),
+				Topic:   "target",
+				Headers: []Header{{"h1", "v1"}, {"h1", "v2"}},
+			}, record)
+		})
+
+		t.Run("keeping partition and timestamp", func(t *testing.T) {
+			record := line.ToProducerRecord("target", true, true)
+
+			assert.Equal(t, 3, *record.Partition)
+			assert.Equal(t, timestamp, record.Timestamp)
+		})
+	})
+}
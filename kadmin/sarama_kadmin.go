@@ -1,10 +1,12 @@
 package kadmin
 
 import (
+	"errors"
 	"github.com/IBM/sarama"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/log"
 	"ktea/config"
+	"ktea/serdes"
 	"ktea/sradmin"
 )
 
@@ -15,6 +17,12 @@ type SaramaKafkaAdmin struct {
 	config   *sarama.Config
 	producer sarama.SyncProducer
 	sra      sradmin.SrAdmin
+	// deserializers are shared by all reads so schemas are only fetched and compiled once
+	deserializers *serdes.FormatDeserializers
+	topicFormats  []config.TopicFormat
+	// validateJsonSchema validates JSON payloads against their registered schema
+	validateJsonSchema bool
+	configDescriber    configDescriber
 }
 
 type ConnectivityCheckStartedMsg struct {
@@ -48,8 +56,10 @@ func ToConnectionDetails(cluster *config.Cluster) ConnectionDetails {
 	}
 
 	connDetails := ConnectionDetails{
-		BootstrapServers: cluster.BootstrapServers,
-		SASLConfig:       saslConfig,
+		BootstrapServers:   cluster.BootstrapServers,
+		SASLConfig:         saslConfig,
+		TopicFormats:       cluster.TopicFormats,
+		ValidateJsonSchema: cluster.ValidatesJsonSchema(),
 	}
 	return connDetails
 }
@@ -58,7 +68,7 @@ func NewSaramaKadmin(cd ConnectionDetails) (Kadmin, error) {
 	cfg := sarama.NewConfig()
 	cfg.Producer.Return.Successes = true
 	cfg.Producer.RequiredAcks = sarama.WaitForAll
-	cfg.Producer.Partitioner = sarama.NewRoundRobinPartitioner
+	cfg.Producer.Partitioner = newManualOrHashPartitioner
 	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
 
 	if cd.SASLConfig != nil {
@@ -85,14 +95,28 @@ func NewSaramaKadmin(cd ConnectionDetails) (Kadmin, error) {
 	}
 
 	return &SaramaKafkaAdmin{
-		client:   client,
-		admin:    admin,
-		addrs:    cd.BootstrapServers,
-		producer: producer,
-		config:   cfg,
+		client:             client,
+		admin:              admin,
+		addrs:              cd.BootstrapServers,
+		producer:           producer,
+		config:             cfg,
+		deserializers:      serdes.NewFormatDeserializers(nil, cd.ValidateJsonSchema),
+		topicFormats:       cd.TopicFormats,
+		validateJsonSchema: cd.ValidateJsonSchema,
+		configDescriber:    newKafkaGoConfigDescriber(cd, cfg.Admin.Timeout),
 	}, nil
 }
 
+// Close closes the producer before the admin and the client it depends on.
+func (ka *SaramaKafkaAdmin) Close() error {
+	ka.configDescriber.Close()
+	return errors.Join(
+		ka.producer.Close(),
+		ka.admin.Close(),
+		ka.client.Close(),
+	)
+}
+
 func SaramaConnectivityChecker(cluster *config.Cluster) tea.Msg {
 	connectedChan := make(chan bool)
 	errChan := make(chan error)
@@ -1,6 +1,7 @@
 package kadmin
 
 import (
+	"ktea/serdes"
 	"ktea/sradmin"
 )
 
@@ -10,4 +11,5 @@ type SraSetter interface {
 
 func (ka *SaramaKafkaAdmin) SetSra(sra sradmin.SrAdmin) {
 	ka.sra = sra
+	ka.deserializers = serdes.NewFormatDeserializers(sra, ka.validateJsonSchema)
 }
@@ -0,0 +1,128 @@
+package kadmin
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"maps"
+	"slices"
+	"sort"
+)
+
+type TopicCGroupLister interface {
+	ListTopicCGroups(topic string) tea.Msg
+}
+
+// TopicConsumerGroup is a consumer group with committed offsets for a topic.
+type TopicConsumerGroup struct {
+	Name    string
+	State   string
+	Members int
+	// Lag is the lag summed over the partitions of the topic only, or UnknownLag.
+	Lag int64
+}
+
+type TopicCGroupListingStartedMsg struct {
+	Err     chan error
+	CGroups chan []TopicConsumerGroup
+}
+
+type TopicCGroupsListedMsg struct {
+	CGroups []TopicConsumerGroup
+}
+
+type TopicCGroupListingErrMsg struct {
+	Err error
+}
+
+func (m *TopicCGroupListingStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case groups := <-m.CGroups:
+		return TopicCGroupsListedMsg{groups}
+	case err := <-m.Err:
+		return TopicCGroupListingErrMsg{err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) ListTopicCGroups(topic string) tea.Msg {
+	errChan := make(chan error)
+	groupsChan := make(chan []TopicConsumerGroup)
+
+	go ka.doListTopicCGroups(topic, groupsChan, errChan)
+
+	return TopicCGroupListingStartedMsg{
+		errChan,
+		groupsChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doListTopicCGroups(
+	topic string,
+	groupsChan chan []TopicConsumerGroup,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+	listGroupResponse, err := ka.admin.ListConsumerGroups()
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	offsetsByGroup := ka.committedOffsetsOfGroups(slices.Collect(maps.Keys(listGroupResponse)))
+
+	var names []string
+	var offsets [][]TopicPartitionOffset
+	for name, groupOffsets := range offsetsByGroup {
+		topicOffsets := offsetsOfTopic(topic, groupOffsets)
+		if len(topicOffsets) == 0 {
+			continue
+		}
+		names = append(names, name)
+		offsets = append(offsets, topicOffsets)
+	}
+
+	var groups []TopicConsumerGroup
+	if len(names) == 0 {
+		groupsChan <- groups
+		return
+	}
+
+	ka.determineLags(offsets...)
+
+	descriptions, err := ka.admin.DescribeConsumerGroups(names)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	groupByName := make(map[string]TopicConsumerGroup)
+	for _, d := range descriptions {
+		groupByName[d.GroupId] = TopicConsumerGroup{
+			Name:    d.GroupId,
+			State:   d.State,
+			Members: len(d.Members),
+		}
+	}
+
+	for i, name := range names {
+		group, ok := groupByName[name]
+		if !ok {
+			group = TopicConsumerGroup{Name: name}
+		}
+		group.Lag = TotalLag(offsets[i])
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+
+	groupsChan <- groups
+}
+
+// offsetsOfTopic keeps the offsets of the topic that were actually committed.
+func offsetsOfTopic(topic string, offsets []TopicPartitionOffset) []TopicPartitionOffset {
+	var topicOffsets []TopicPartitionOffset
+	for _, o := range offsets {
+		if o.Topic == topic && o.Offset >= 0 {
+			topicOffsets = append(topicOffsets, o)
+		}
+	}
+	return topicOffsets
+}
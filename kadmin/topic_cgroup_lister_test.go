@@ -0,0 +1,103 @@
+package kadmin
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTopicConsumerGroups(t *testing.T) {
+	t.Run("List groups consuming a topic", func(t *testing.T) {
+		topic := topicName()
+		otherTopic := topicName()
+		// given
+		for _, name := range []string{topic, otherTopic} {
+			msg := ka.CreateTopic(TopicCreationDetails{
+				Name:              name,
+				NumPartitions:     1,
+				ReplicationFactor: 1,
+			}).(TopicCreationStartedMsg)
+
+			switch msg := msg.AwaitCompletion().(type) {
+			case TopicCreatedMsg:
+			case TopicCreationErrMsg:
+				t.Fatal("Unable to create topic", msg.Err)
+			}
+
+			for i := 0; i < 10; i++ {
+				ka.PublishRecord(&ProducerRecord{
+					Key:   []byte("key"),
+					Value: []byte("value"),
+					Topic: name,
+				})
+			}
+		}
+
+		groups := map[string]string{
+			"topic-cgroups-test-group":       topic,
+			"topic-cgroups-test-other-group": otherTopic,
+		}
+		for groupName, groupTopic := range groups {
+			consumerGroup, err := sarama.NewConsumerGroupFromClient(groupName, kafkaClient())
+			if err != nil {
+				t.Fatal("Unable to create Consumer Group.", err)
+			}
+
+			handler := testConsumer{ExpectedMsgCount: 10}
+			consumerGroup.Consume(context.WithoutCancel(context.Background()), []string{groupTopic}, &handler)
+
+			defer consumerGroup.Close()
+		}
+
+		// when
+		msg := ka.ListTopicCGroups(topic).(TopicCGroupListingStartedMsg)
+
+		// then
+		select {
+		case groups := <-msg.CGroups:
+			assert.Len(t, groups, 1)
+			assert.Equal(t, "topic-cgroups-test-group", groups[0].Name)
+			assert.Equal(t, "Stable", groups[0].State)
+			assert.Equal(t, 1, groups[0].Members)
+			assert.Equal(t, int64(1), groups[0].Lag)
+		case err := <-msg.Err:
+			t.Fatal("Error while listing groups", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test timed out waiting for consumer groups")
+		}
+
+		// clean up
+		ka.DeleteTopic(topic)
+		ka.DeleteTopic(otherTopic)
+	})
+
+	t.Run("No groups consuming a topic", func(t *testing.T) {
+		topic := topicName()
+
+		msg := ka.ListTopicCGroups(topic).(TopicCGroupListingStartedMsg)
+
+		select {
+		case groups := <-msg.CGroups:
+			assert.Empty(t, groups)
+		case err := <-msg.Err:
+			t.Fatal("Error while listing groups", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test timed out waiting for consumer groups")
+		}
+	})
+}
+
+func TestOffsetsOfTopic(t *testing.T) {
+	offsets := []TopicPartitionOffset{
+		{Topic: "topic", Partition: 0, Offset: 10},
+		{Topic: "topic", Partition: 1, Offset: -1},
+		{Topic: "other", Partition: 0, Offset: 10},
+	}
+
+	assert.Equal(t, []TopicPartitionOffset{
+		{Topic: "topic", Partition: 0, Offset: 10},
+	}, offsetsOfTopic("topic", offsets))
+	assert.Empty(t, offsetsOfTopic("unknown", offsets))
+}
@@ -38,10 +38,10 @@ func TestCreateTopic(t *testing.T) {
 			return
 		}
 
-		assert.Contains(t, topics, Topic{topic, 2, 1, 0})
+		assert.Contains(t, topics, Topic{Name: topic, Partitions: 2, Replicas: 1, Isr: 2})
 
 		// and
-		var configs map[string]string
+		var configs map[string]TopicConfig
 		configListingStartedMsg := ka.ListConfigs(topic).(TopicConfigListingStartedMsg)
 		msg = configListingStartedMsg.AwaitCompletion()
 		switch msg := msg.(type) {
@@ -52,7 +52,7 @@ func TestCreateTopic(t *testing.T) {
 			return
 		}
 
-		assert.Equal(t, "lz4", configs["compression.type"])
+		assert.Equal(t, "lz4", configs["compression.type"].Value)
 
 		t.Run("Creation fails", func(t *testing.T) {
 			// when
@@ -44,8 +44,8 @@ func TestDeleteTopic(t *testing.T) {
 				case err := <-listTopicsMsg.Err:
 					t.Error(t, "Failed to list topics", err)
 				}
-				assert.Contains(c, topics, Topic{topic1, 2, 1, 0})
-				assert.NotContains(c, topics, Topic{topic2, 2, 1, 0})
+				assert.Contains(c, topics, Topic{Name: topic1, Partitions: 2, Replicas: 1, Isr: 2})
+				assert.NotContains(c, topics, Topic{Name: topic2, Partitions: 2, Replicas: 1, Isr: 2})
 			}, 2*time.Second, 10*time.Millisecond)
 			// clean up
 			ka.DeleteTopic(topic1)
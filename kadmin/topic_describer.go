@@ -0,0 +1,154 @@
+package kadmin
+
+import (
+	"errors"
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+	"sort"
+)
+
+// NoLeader is used as leader and as watermarks of a partition without an available leader.
+const NoLeader = -1
+
+type TopicDescriber interface {
+	DescribeTopic(topic string) tea.Msg
+}
+
+type PartitionDetails struct {
+	ID              int32
+	Leader          int32
+	Replicas        []int32
+	Isr             []int32
+	OfflineReplicas []int32
+	LowWatermark    int64
+	HighWatermark   int64
+}
+
+// MessageCount returns the number of records between the low and high watermark,
+// compacted or aborted records are included.
+func (p *PartitionDetails) MessageCount() int64 {
+	if !p.HasWatermarks() {
+		return 0
+	}
+	return p.HighWatermark - p.LowWatermark
+}
+
+// HasWatermarks returns false when the watermarks are unknown, as the partition has no leader.
+func (p *PartitionDetails) HasWatermarks() bool {
+	return p.LowWatermark != NoLeader && p.HighWatermark != NoLeader
+}
+
+func (p *PartitionDetails) UnderReplicated() bool {
+	return len(p.Isr) < len(p.Replicas)
+}
+
+type TopicDescriptionStartedMsg struct {
+	Err        chan error
+	Partitions chan []PartitionDetails
+}
+
+type TopicDescribedMsg struct {
+	Partitions []PartitionDetails
+}
+
+type TopicDescriptionErrorMsg struct {
+	Err error
+}
+
+func (m *TopicDescriptionStartedMsg) AwaitCompletion() tea.Msg {
+	select {
+	case partitions := <-m.Partitions:
+		return TopicDescribedMsg{partitions}
+	case err := <-m.Err:
+		return TopicDescriptionErrorMsg{err}
+	}
+}
+
+func (ka *SaramaKafkaAdmin) DescribeTopic(topic string) tea.Msg {
+	errChan := make(chan error)
+	partitionsChan := make(chan []PartitionDetails)
+
+	go ka.doDescribeTopic(topic, partitionsChan, errChan)
+
+	return TopicDescriptionStartedMsg{
+		errChan,
+		partitionsChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doDescribeTopic(
+	topic string,
+	partitionsChan chan []PartitionDetails,
+	errChan chan error,
+) {
+	maybeIntroduceLatency()
+	// the cached metadata might be outdated, the ISR in particular
+	if err := ka.client.RefreshMetadata(topic); err != nil {
+		errChan <- err
+		return
+	}
+
+	partitions, err := ka.client.Partitions(topic)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	var details []PartitionDetails
+	for _, partition := range partitions {
+		d, err := ka.describePartition(topic, partition)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		details = append(details, d)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].ID < details[j].ID
+	})
+
+	partitionsChan <- details
+}
+
+func (ka *SaramaKafkaAdmin) describePartition(topic string, partition int32) (PartitionDetails, error) {
+	d := PartitionDetails{
+		ID:            partition,
+		Leader:        NoLeader,
+		LowWatermark:  NoLeader,
+		HighWatermark: NoLeader,
+	}
+
+	var err error
+	if d.Replicas, err = ka.client.Replicas(topic, partition); !replicasKnown(err) {
+		return d, err
+	}
+	if d.Isr, err = ka.client.InSyncReplicas(topic, partition); !replicasKnown(err) {
+		return d, err
+	}
+	if d.OfflineReplicas, err = ka.client.OfflineReplicas(topic, partition); !replicasKnown(err) {
+		return d, err
+	}
+
+	leader, err := ka.client.Leader(topic, partition)
+	if errors.Is(err, sarama.ErrLeaderNotAvailable) {
+		// watermarks can only be fetched from the leader
+		return d, nil
+	} else if err != nil {
+		return d, err
+	}
+	d.Leader = leader.ID()
+
+	if d.LowWatermark, err = ka.client.GetOffset(topic, partition, sarama.OffsetOldest); err != nil {
+		return d, err
+	}
+	if d.HighWatermark, err = ka.client.GetOffset(topic, partition, sarama.OffsetNewest); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// replicasKnown reports whether the replicas were returned, which is also
+// the case when some of them are not available.
+func replicasKnown(err error) bool {
+	return err == nil || errors.Is(err, sarama.ErrReplicaNotAvailable)
+}
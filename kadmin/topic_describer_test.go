@@ -0,0 +1,64 @@
+package kadmin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDescribeTopic(t *testing.T) {
+	t.Run("Describe partitions", func(t *testing.T) {
+		topic := topicName()
+		// given
+		msg := ka.CreateTopic(TopicCreationDetails{
+			Name:              topic,
+			NumPartitions:     2,
+			Properties:        nil,
+			ReplicationFactor: 1,
+		}).(TopicCreationStartedMsg)
+
+		switch msg.AwaitCompletion().(type) {
+		case TopicCreatedMsg:
+		case TopicCreationErrMsg:
+			t.Fatal("Unable to create topic", msg.Err)
+		}
+
+		partition := 1
+		for i := 0; i < 3; i++ {
+			psm := ka.PublishRecord(&ProducerRecord{
+				Key:       []byte("key"),
+				Value:     []byte("value"),
+				Topic:     topic,
+				Partition: &partition,
+			})
+			psm.AwaitCompletion()
+		}
+
+		// when
+		describeMsg := ka.DescribeTopic(topic).(TopicDescriptionStartedMsg)
+
+		// then
+		select {
+		case partitions := <-describeMsg.Partitions:
+			assert.Len(t, partitions, 2)
+			assert.Equal(t, int32(0), partitions[0].ID)
+			assert.Equal(t, int64(0), partitions[0].MessageCount())
+			assert.Equal(t, int32(1), partitions[1].ID)
+			assert.Equal(t, int64(0), partitions[1].LowWatermark)
+			assert.Equal(t, int64(3), partitions[1].HighWatermark)
+			assert.Equal(t, int64(3), partitions[1].MessageCount())
+			assert.Len(t, partitions[1].Replicas, 1)
+			assert.Equal(t, partitions[1].Replicas, partitions[1].Isr)
+			assert.Equal(t, partitions[1].Replicas[0], partitions[1].Leader)
+			assert.Empty(t, partitions[1].OfflineReplicas)
+			assert.False(t, partitions[1].UnderReplicated())
+		case err := <-describeMsg.Err:
+			t.Fatal("Error while describing topic", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Test timed out waiting for partitions")
+		}
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+}
@@ -2,6 +2,7 @@ package kadmin
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
 )
 
 type TopicLister interface {
@@ -34,7 +35,11 @@ type Topic struct {
 	Name       string
 	Partitions int
 	Replicas   int
-	Isr        int
+	// Isr is the number of in-sync replicas summed over all partitions,
+	// it equals Partitions * Replicas when no partition is under-replicated.
+	Isr int
+	// Stats are nil until listed through TopicStatsLister.
+	Stats *TopicStats
 }
 
 func (ka *SaramaKafkaAdmin) ListTopics() tea.Msg {
@@ -54,6 +59,12 @@ func (ka *SaramaKafkaAdmin) doListTopics(errChan chan error, topicsChan chan []T
 	listResult, err := ka.admin.ListTopics()
 	if err != nil {
 		errChan <- err
+		return
+	}
+	// the cached metadata might be outdated, the ISR in particular
+	if err := ka.client.RefreshMetadata(); err != nil {
+		errChan <- err
+		return
 	}
 	partByTopic := make(map[string]Topic)
 	for name, topic := range listResult {
@@ -61,12 +72,25 @@ func (ka *SaramaKafkaAdmin) doListTopics(errChan chan error, topicsChan chan []T
 			Name:       name,
 			Partitions: int(topic.NumPartitions),
 			Replicas:   int(topic.ReplicationFactor),
-			Isr:        0,
+			Isr:        ka.inSyncReplicaCount(name, topic.NumPartitions),
 		}
 	}
 	var topics []Topic
 	for _, t := range partByTopic {
-		topics = append(topics, Topic{t.Name, t.Partitions, t.Replicas, t.Isr})
+		topics = append(topics, t)
 	}
 	topicsChan <- topics
 }
+
+func (ka *SaramaKafkaAdmin) inSyncReplicaCount(topic string, partitions int32) int {
+	count := 0
+	for partition := int32(0); partition < partitions; partition++ {
+		isr, err := ka.client.InSyncReplicas(topic, partition)
+		if err != nil {
+			log.Warn("Unable to determine in-sync replicas", "topic", topic, "partition", partition, "err", err)
+			continue
+		}
+		count += len(isr)
+	}
+	return count
+}
@@ -36,8 +36,8 @@ func TestListTopics(t *testing.T) {
 				t.Error(t, "Failed to list topics", err)
 				return
 			}
-			assert.Contains(t, topics, Topic{topic1, 2, 1, 0})
-			assert.Contains(t, topics, Topic{topic2, 1, 1, 0})
+			assert.Contains(t, topics, Topic{Name: topic1, Partitions: 2, Replicas: 1, Isr: 2})
+			assert.Contains(t, topics, Topic{Name: topic2, Partitions: 1, Replicas: 1, Isr: 1})
 		}, 2*time.Second, 10*time.Millisecond)
 
 		// clean up
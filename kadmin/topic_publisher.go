@@ -1,20 +1,27 @@
 package kadmin
 
 import (
+	"context"
 	"github.com/IBM/sarama"
 	tea "github.com/charmbracelet/bubbletea"
+	"time"
 )
 
 type Publisher interface {
 	PublishRecord(p *ProducerRecord) PublicationStartedMsg
+	PublishRecords(ctx context.Context, records []ProducerRecord) BulkPublicationStartedMsg
 }
 
 type ProducerRecord struct {
-	Key       string
-	Value     string
+	// Key is nil for records without a key
+	Key []byte
+	// Value is nil for tombstones
+	Value     []byte
 	Topic     string
 	Partition *int
-	Headers   map[string]string
+	Headers   []Header
+	// Timestamp is set by the producer when zero
+	Timestamp time.Time
 }
 
 type PublicationStartedMsg struct {
@@ -55,31 +62,146 @@ func (ka *SaramaKafkaAdmin) doPublishRecord(
 	published chan bool,
 ) {
 	maybeIntroduceLatency()
-	var partition int32
-	if p.Partition == nil {
-		ka.config.Producer.Partitioner = sarama.NewHashPartitioner
-	} else {
+	_, _, err := ka.producer.SendMessage(ka.newProducerMessage(p))
+	if err != nil {
+		errChan <- err
+	}
+	published <- true
+}
+
+// anyPartition lets the partitioner hash the key of a message to choose its partition.
+const anyPartition = -1
+
+// manualOrHashPartitioner keeps the partition of a message when it is set and hashes its key otherwise.
+// Sarama fixes the partitioner of a topic once it is first used, so it cannot be switched per message.
+type manualOrHashPartitioner struct {
+	hash sarama.Partitioner
+}
+
+func newManualOrHashPartitioner(topic string) sarama.Partitioner {
+	return &manualOrHashPartitioner{sarama.NewHashPartitioner(topic)}
+}
+
+func (p *manualOrHashPartitioner) Partition(msg *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if msg.Partition == anyPartition {
+		return p.hash.Partition(msg, numPartitions)
+	}
+	return msg.Partition, nil
+}
+
+func (p *manualOrHashPartitioner) RequiresConsistency() bool {
+	return true
+}
+
+func (ka *SaramaKafkaAdmin) newProducerMessage(p *ProducerRecord) *sarama.ProducerMessage {
+	var partition int32 = anyPartition
+	if p.Partition != nil {
 		partition = int32(*p.Partition)
-		ka.config.Producer.Partitioner = sarama.NewManualPartitioner
 	}
 
 	var headers []sarama.RecordHeader
-	for key, value := range p.Headers {
+	for _, header := range p.Headers {
 		headers = append(headers, sarama.RecordHeader{
-			Key:   []byte(key),
-			Value: []byte(value),
+			Key:   []byte(header.Key),
+			Value: []byte(header.Value),
 		})
 	}
 
-	_, _, err := ka.producer.SendMessage(&sarama.ProducerMessage{
+	return &sarama.ProducerMessage{
 		Topic:     p.Topic,
-		Key:       sarama.StringEncoder(p.Key),
-		Value:     sarama.StringEncoder(p.Value),
+		Key:       byteEncoder(p.Key),
+		Value:     byteEncoder(p.Value),
 		Partition: partition,
 		Headers:   headers,
-	})
-	if err != nil {
-		errChan <- err
+		Timestamp: p.Timestamp,
+	}
+}
+
+// byteEncoder keeps nil as nil, so that it is published as null instead of as an empty array.
+func byteEncoder(data []byte) sarama.Encoder {
+	if data == nil {
+		return nil
+	}
+	return sarama.ByteEncoder(data)
+}
+
+// bulkProgressInterval is the number of records after which progress is reported
+const bulkProgressInterval = 100
+
+type BulkPublicationStartedMsg struct {
+	Progress chan BulkPublicationProgressMsg
+	Total    int
+}
+
+// BulkPublicationProgressMsg reports the records published so far, along with
+// the failures since the previous report.
+type BulkPublicationProgressMsg struct {
+	Published int
+	Failed    int
+	Total     int
+	Failures  []RecordPublicationFailure
+}
+
+// RecordPublicationFailure is a record that could not be published, Index is its position
+// within the published records.
+type RecordPublicationFailure struct {
+	Index int
+	Err   error
+}
+
+type BulkPublicationEndedMsg struct{}
+
+// AwaitProgress waits for the next progress report, returning
+// BulkPublicationEndedMsg once all records are processed.
+func (b *BulkPublicationStartedMsg) AwaitProgress() tea.Msg {
+	progress, ok := <-b.Progress
+	if !ok {
+		return BulkPublicationEndedMsg{}
+	}
+	return progress
+}
+
+// PublishRecords publishes the records one after the other, a failing record does not stop the others
+// from being published. Cancelling ctx stops publishing the remaining records.
+func (ka *SaramaKafkaAdmin) PublishRecords(ctx context.Context, records []ProducerRecord) BulkPublicationStartedMsg {
+	progress := make(chan BulkPublicationProgressMsg)
+
+	go ka.doPublishRecords(ctx, records, progress)
+
+	return BulkPublicationStartedMsg{
+		Progress: progress,
+		Total:    len(records),
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doPublishRecords(
+	ctx context.Context,
+	records []ProducerRecord,
+	progress chan BulkPublicationProgressMsg,
+) {
+	defer close(progress)
+
+	report := BulkPublicationProgressMsg{Total: len(records)}
+	for i := range records {
+		if ctx.Err() != nil {
+			return
+		}
+		_, _, err := ka.producer.SendMessage(ka.newProducerMessage(&records[i]))
+		if err != nil {
+			report.Failed++
+			report.Failures = append(report.Failures, RecordPublicationFailure{Index: i, Err: err})
+		} else {
+			report.Published++
+		}
+
+		processed := report.Published + report.Failed
+		if processed%bulkProgressInterval == 0 || processed == len(records) {
+			select {
+			case progress <- report:
+			case <-ctx.Done():
+				return
+			}
+			report.Failures = nil
+		}
 	}
-	published <- true
 }
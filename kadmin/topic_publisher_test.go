@@ -2,6 +2,7 @@ package kadmin
 
 import (
 	"context"
+	"github.com/IBM/sarama"
 	kgo "github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -24,8 +25,8 @@ func TestPublish(t *testing.T) {
 		assert.EventuallyWithT(t, func(c *assert.CollectT) {
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic: topic,
-				Key:   "123",
-				Value: "{\"id\":\"123\"}",
+				Key:   []byte("123"),
+				Value: []byte("{\"id\":\"123\"}"),
 			})
 
 			select {
@@ -39,7 +40,7 @@ func TestPublish(t *testing.T) {
 		// then
 		ctx, cancel := context.WithCancel(context.Background())
 		rsm := ka.ReadRecords(ctx, ReadDetails{
-			Topic:      &Topic{topic, 1, 1, 1},
+			Topic:      &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 			StartPoint: Beginning,
 			Limit:      1,
 		}).(ReadingStartedMsg)
@@ -67,11 +68,11 @@ func TestPublish(t *testing.T) {
 		assert.EventuallyWithT(t, func(c *assert.CollectT) {
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic: topic,
-				Key:   "123",
-				Value: "{\"id\":\"123\"}",
-				Headers: map[string]string{
-					"id":   "123",
-					"user": "456",
+				Key:   []byte("123"),
+				Value: []byte("{\"id\":\"123\"}"),
+				Headers: []Header{
+					{"id", "123"},
+					{"user", "456"},
 				},
 			})
 
@@ -86,7 +87,7 @@ func TestPublish(t *testing.T) {
 		// then
 		ctx, cancel := context.WithCancel(context.Background())
 		rsm := ka.ReadRecords(ctx, ReadDetails{
-			Topic: &Topic{topic, 1, 1, 1},
+			Topic: &Topic{Name: topic, Partitions: 1, Replicas: 1, Isr: 1},
 			Limit: 1,
 		}).(ReadingStartedMsg)
 
@@ -135,8 +136,8 @@ func TestPublish(t *testing.T) {
 			var partition = 2
 			psm := ka.PublishRecord(&ProducerRecord{
 				Topic:     topic,
-				Key:       "123",
-				Value:     "{\"id\":\"123\"}",
+				Key:       []byte("123"),
+				Value:     []byte("{\"id\":\"123\"}"),
 				Partition: &partition,
 			})
 
@@ -150,7 +151,7 @@ func TestPublish(t *testing.T) {
 
 		// then
 		rsm := ka.ReadRecords(context.Background(), ReadDetails{
-			Topic:      &Topic{topic, 2, 1, 1},
+			Topic:      &Topic{Name: topic, Partitions: 2, Replicas: 1, Isr: 1},
 			Partitions: []int{2},
 		}).(ReadingStartedMsg)
 		assert.EventuallyWithT(t, func(c *assert.CollectT) {
@@ -163,4 +164,88 @@ func TestPublish(t *testing.T) {
 		ka.DeleteTopic(topic)
 	})
 
+	t.Run("Publish records in bulk", func(t *testing.T) {
+		topic := topicName()
+		// given
+		createTopic(t, []kgo.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     2,
+				ReplicationFactor: 1,
+			},
+		})
+		partition := 1
+		invalidPartition := 5
+		timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		// when
+		bsm := ka.PublishRecords(context.Background(), []ProducerRecord{
+			{Topic: topic, Key: []byte("1"), Value: []byte("one"), Partition: &partition, Timestamp: timestamp},
+			{Topic: topic, Key: []byte("2"), Value: []byte("two"), Partition: &invalidPartition},
+		})
+
+		var progress BulkPublicationProgressMsg
+		for {
+			msg := bsm.AwaitProgress()
+			if _, ok := msg.(BulkPublicationEndedMsg); ok {
+				break
+			}
+			progress = msg.(BulkPublicationProgressMsg)
+		}
+
+		// then
+		assert.Equal(t, 1, progress.Published)
+		assert.Equal(t, 1, progress.Failed)
+		assert.Equal(t, 1, progress.Failures[0].Index)
+
+		rsm := ka.ReadRecords(context.Background(), ReadDetails{
+			Topic:      &Topic{Name: topic, Partitions: 2, Replicas: 1, Isr: 1},
+			Partitions: []int{1},
+			StartPoint: Beginning,
+			Limit:      1,
+		}).(ReadingStartedMsg)
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			record := <-rsm.ConsumerRecord
+			assert.Equal(c, "one", record.Value)
+			assert.Equal(c, timestamp, record.Timestamp.UTC())
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// clean up
+		ka.DeleteTopic(topic)
+	})
+}
+
+func TestManualOrHashPartitioner(t *testing.T) {
+	partitioner := newManualOrHashPartitioner("topic")
+
+	t.Run("keep the partition of the message", func(t *testing.T) {
+		partition, err := partitioner.Partition(&sarama.ProducerMessage{
+			Key:       sarama.StringEncoder("key"),
+			Partition: 2,
+		}, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), partition)
+	})
+
+	t.Run("hash the key without a partition", func(t *testing.T) {
+		msg := &sarama.ProducerMessage{
+			Key:       sarama.StringEncoder("key"),
+			Partition: anyPartition,
+		}
+		expected, _ := sarama.NewHashPartitioner("topic").Partition(msg, 3)
+
+		for i := 0; i < 10; i++ {
+			partition, err := partitioner.Partition(msg, 3)
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, partition)
+		}
+	})
+}
+
+func TestByteEncoder(t *testing.T) {
+	assert.Nil(t, byteEncoder(nil))
+	assert.Equal(t, sarama.ByteEncoder{}, byteEncoder([]byte{}))
+	assert.Equal(t, sarama.ByteEncoder("key"), byteEncoder([]byte("key")))
 }
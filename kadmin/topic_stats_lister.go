@@ -0,0 +1,293 @@
+package kadmin
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+	"time"
+)
+
+// topicStatsBatchSize limits the topics of which the stats are gathered at once,
+// every batch is reported separately so stats show up while the rest is loading.
+const topicStatsBatchSize = 50
+
+// lastBatchMaxBytes is the amount of data fetched per partition to determine the timestamp of its last record.
+const lastBatchMaxBytes = 256 * 1024
+
+type TopicStatsLister interface {
+	ListTopicStats(ctx context.Context, topics []string) tea.Msg
+}
+
+// TopicStats are gathered separately from the topic listing
+// as they are expensive to determine on clusters with a lot of topics.
+type TopicStats struct {
+	// MessageCount is the sum of the high minus low watermarks of all partitions.
+	MessageCount int64
+	// Size is the on-disk size in bytes of all replicas, -1 when unknown.
+	Size int64
+	// LastTimestamp is the timestamp of the most recent record, zero when the topic is empty.
+	LastTimestamp time.Time
+}
+
+type TopicStatsListingStartedMsg struct {
+	Stats chan map[string]TopicStats
+	Err   chan error
+	// ListingId is set by the caller to tell the messages of this listing apart from those of an earlier one.
+	ListingId int
+}
+
+type TopicStatsListedMsg struct {
+	Stats     map[string]TopicStats
+	ListingId int
+}
+
+type TopicStatsListingErrorMsg struct {
+	Err       error
+	ListingId int
+}
+
+type TopicStatsListingEndedMsg struct {
+	ListingId int
+}
+
+// AwaitNext returns the stats of the next batch of topics or TopicStatsListingEndedMsg when all stats are listed,
+// tagged with the ListingId.
+func (m *TopicStatsListingStartedMsg) AwaitNext() tea.Msg {
+	select {
+	case stats, ok := <-m.Stats:
+		if !ok {
+			return TopicStatsListingEndedMsg{m.ListingId}
+		}
+		return TopicStatsListedMsg{stats, m.ListingId}
+	case err := <-m.Err:
+		return TopicStatsListingErrorMsg{err, m.ListingId}
+	}
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+func (ka *SaramaKafkaAdmin) ListTopicStats(ctx context.Context, topics []string) tea.Msg {
+	statsChan := make(chan map[string]TopicStats)
+	errChan := make(chan error)
+
+	go ka.doListTopicStats(ctx, topics, statsChan, errChan)
+
+	return TopicStatsListingStartedMsg{
+		Stats: statsChan,
+		Err:   errChan,
+	}
+}
+
+func (ka *SaramaKafkaAdmin) doListTopicStats(
+	ctx context.Context,
+	topics []string,
+	statsChan chan map[string]TopicStats,
+	errChan chan error,
+) {
+	// closing makes a pending AwaitNext return when the listing got cancelled
+	defer close(statsChan)
+	maybeIntroduceLatency()
+	sizes, err := ka.topicSizes()
+	if err != nil {
+		// log dirs might not be accessible, the other stats are still useful
+		log.Warn("Unable to determine topic sizes", "err", err)
+	}
+
+	for start := 0; start < len(topics); start += topicStatsBatchSize {
+		end := min(start+topicStatsBatchSize, len(topics))
+		stats, err := ka.topicStats(topics[start:end], sizes)
+		if err != nil {
+			select {
+			case errChan <- err:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		select {
+		case statsChan <- stats:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// topicSizes sums the size of the partitions of each topic over all log dirs of all brokers.
+func (ka *SaramaKafkaAdmin) topicSizes() (map[string]int64, error) {
+	var brokerIds []int32
+	for _, broker := range ka.client.Brokers() {
+		brokerIds = append(brokerIds, broker.ID())
+	}
+
+	logDirsByBroker, err := ka.admin.DescribeLogDirs(brokerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]int64)
+	for _, logDirs := range logDirsByBroker {
+		for _, logDir := range logDirs {
+			if logDir.ErrorCode != sarama.ErrNoError {
+				continue
+			}
+			for _, topic := range logDir.Topics {
+				for _, partition := range topic.Partitions {
+					sizes[topic.Topic] += partition.Size
+				}
+			}
+		}
+	}
+	return sizes, nil
+}
+
+// topicStats determines the stats of the topics with as few requests as possible,
+// the watermarks and last records of all partitions led by the same broker are requested at once.
+func (ka *SaramaKafkaAdmin) topicStats(topics []string, sizes map[string]int64) (map[string]TopicStats, error) {
+	stats := make(map[string]TopicStats)
+	leaders := make(map[int32]*sarama.Broker)
+	partitionsByLeader := make(map[int32][]topicPartition)
+	for _, topic := range topics {
+		size, ok := sizes[topic]
+		if !ok {
+			size = -1
+		}
+		stats[topic] = TopicStats{Size: size}
+
+		partitions, err := ka.client.Partitions(topic)
+		if err != nil {
+			// the topic might have been deleted since it was listed
+			log.Warn("Unable to list partitions", "topic", topic, "err", err)
+			continue
+		}
+		for _, partition := range partitions {
+			leader, err := ka.client.Leader(topic, partition)
+			if err != nil {
+				log.Warn("Unable to determine leader", "topic", topic, "partition", partition, "err", err)
+				continue
+			}
+			leaders[leader.ID()] = leader
+			partitionsByLeader[leader.ID()] = append(
+				partitionsByLeader[leader.ID()],
+				topicPartition{topic, partition},
+			)
+		}
+	}
+
+	for id, partitions := range partitionsByLeader {
+		leader := leaders[id]
+		lows, err := listOffsets(leader, partitions, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		highs, err := listOffsets(leader, partitions, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+
+		var nonEmpty []topicPartition
+		for _, tp := range partitions {
+			low, lowOk := lows[tp]
+			high, highOk := highs[tp]
+			if !lowOk || !highOk {
+				continue
+			}
+			s := stats[tp.topic]
+			s.MessageCount += high - low
+			stats[tp.topic] = s
+			if high > low {
+				nonEmpty = append(nonEmpty, tp)
+			}
+		}
+
+		timestamps, err := lastTimestamps(leader, nonEmpty, highs)
+		if err != nil {
+			return nil, err
+		}
+		for tp, timestamp := range timestamps {
+			s := stats[tp.topic]
+			if timestamp.After(s.LastTimestamp) {
+				s.LastTimestamp = timestamp
+			}
+			stats[tp.topic] = s
+		}
+	}
+	return stats, nil
+}
+
+func listOffsets(broker *sarama.Broker, partitions []topicPartition, time int64) (map[topicPartition]int64, error) {
+	request := &sarama.OffsetRequest{Version: 1}
+	for _, tp := range partitions {
+		request.AddBlock(tp.topic, tp.partition, time, 1)
+	}
+
+	response, err := broker.GetAvailableOffsets(request)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[topicPartition]int64)
+	for _, tp := range partitions {
+		block := response.GetBlock(tp.topic, tp.partition)
+		if block == nil || block.Err != sarama.ErrNoError {
+			continue
+		}
+		offsets[tp] = block.Offset
+	}
+	return offsets, nil
+}
+
+// lastTimestamps fetches the batch holding the last record of each partition, of
+// which the max timestamp is the timestamp of the most recent record.
+func lastTimestamps(
+	broker *sarama.Broker,
+	partitions []topicPartition,
+	highs map[topicPartition]int64,
+) (map[topicPartition]time.Time, error) {
+	timestamps := make(map[topicPartition]time.Time)
+	if len(partitions) == 0 {
+		return timestamps, nil
+	}
+
+	request := &sarama.FetchRequest{
+		Version:   4,
+		MinBytes:  1,
+		MaxBytes:  sarama.MaxResponseSize,
+		Isolation: sarama.ReadUncommitted,
+	}
+	for _, tp := range partitions {
+		request.AddBlock(tp.topic, tp.partition, highs[tp]-1, lastBatchMaxBytes, -1)
+	}
+
+	response, err := broker.Fetch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tp := range partitions {
+		block := response.GetBlock(tp.topic, tp.partition)
+		if block == nil || block.Err != sarama.ErrNoError {
+			continue
+		}
+		var last time.Time
+		for _, records := range block.RecordsSet {
+			if records.RecordBatch != nil && records.RecordBatch.MaxTimestamp.After(last) {
+				last = records.RecordBatch.MaxTimestamp
+			}
+			if records.MsgSet != nil {
+				for _, msg := range records.MsgSet.Messages {
+					if msg.Msg != nil && msg.Msg.Timestamp.After(last) {
+						last = msg.Msg.Timestamp
+					}
+				}
+			}
+		}
+		if !last.IsZero() {
+			timestamps[tp] = last
+		}
+	}
+	return timestamps, nil
+}
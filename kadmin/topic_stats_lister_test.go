@@ -0,0 +1,63 @@
+package kadmin
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestListTopicStats(t *testing.T) {
+	t.Run("List stats of topics", func(t *testing.T) {
+		topic := topicName()
+		emptyTopic := topicName()
+		// given
+		for _, name := range []string{topic, emptyTopic} {
+			msg := ka.CreateTopic(TopicCreationDetails{
+				Name:              name,
+				NumPartitions:     2,
+				Properties:        nil,
+				ReplicationFactor: 1,
+			}).(TopicCreationStartedMsg)
+
+			switch msg.AwaitCompletion().(type) {
+			case TopicCreatedMsg:
+			case TopicCreationErrMsg:
+				t.Fatal("Unable to create topic", msg.Err)
+			}
+		}
+
+		before := time.Now().Add(-time.Second)
+		for i := 0; i < 5; i++ {
+			psm := ka.PublishRecord(&ProducerRecord{
+				Key:   []byte("key"),
+				Value: []byte("value"),
+				Topic: topic,
+			})
+			psm.AwaitCompletion()
+		}
+
+		// when
+		msg := ka.ListTopicStats(context.Background(), []string{topic, emptyTopic}).(TopicStatsListingStartedMsg)
+
+		// then
+		switch msg := msg.AwaitNext().(type) {
+		case TopicStatsListedMsg:
+			stats := msg.Stats[topic]
+			assert.Equal(t, int64(5), stats.MessageCount)
+			assert.Greater(t, stats.Size, int64(0))
+			assert.True(t, stats.LastTimestamp.After(before))
+
+			empty := msg.Stats[emptyTopic]
+			assert.Equal(t, int64(0), empty.MessageCount)
+			assert.True(t, empty.LastTimestamp.IsZero())
+		default:
+			t.Fatal("Unable to list topic stats", msg)
+		}
+		assert.IsType(t, TopicStatsListingEndedMsg{}, msg.AwaitNext())
+
+		// clean up
+		ka.DeleteTopic(topic)
+		ka.DeleteTopic(emptyTopic)
+	})
+}
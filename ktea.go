@@ -177,14 +177,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case 0:
 			if m.topicsTabCtrl == nil {
 				var cmd tea.Cmd
-				m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka)
+				m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka, m.kaInstantiator)
 				cmds = append(cmds, cmd)
 			}
 			m.tabCtrl = m.topicsTabCtrl
 		case 1:
 			if m.cgroupsTabCtrl == nil {
 				var cmd tea.Cmd
-				m.cgroupsTabCtrl, cmd = cgroups_tab.New(m.ka, m.ka, m.ka)
+				m.cgroupsTabCtrl, cmd = cgroups_tab.New(m.ka, m.ka, m.ka, m.ka)
 				cmds = append(cmds, cmd)
 			}
 			m.tabCtrl = m.cgroupsTabCtrl
@@ -264,7 +264,7 @@ func (m *Model) initTopicsTabOrError(cluster *config.Cluster) (tea.Cmd, error) {
 		m.tabCtrl, cmd = con_err_tab.New(err, cluster)
 		return cmd, err
 	} else {
-		m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka)
+		m.topicsTabCtrl, cmd = topics_tab.New(m.ktx, m.ka, m.kaInstantiator)
 		m.tabCtrl = m.topicsTabCtrl
 		return cmd, nil
 	}
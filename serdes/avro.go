@@ -1,100 +1,36 @@
 package serdes
 
 import (
-	"bytes"
-	"encoding/binary"
 	"encoding/json"
 	"github.com/linkedin/goavro/v2"
 	"ktea/sradmin"
 )
 
 type GoAvroAvroDeserializer struct {
-	subjects []sradmin.Subject
-	sra      sradmin.SrAdmin
+	decoders *decoderCache
 }
 
 func (d *GoAvroAvroDeserializer) Deserialize(data []byte) (string, error) {
-	if data == nil || len(data) == 0 {
-		return "", nil
-	}
-
-	schemaId, isAvro := isAvroWithSchemaID(data)
-
-	if isAvro {
-		if schema, err := d.getSchema(schemaId); err != nil {
-			return "", err
-		} else {
-			var codec *goavro.Codec
-			if codec, err = goavro.NewCodec(schema.Schema); err != nil {
-				return "", err
-			}
-
-			deserData, _, err := codec.NativeFromBinary(data[5:])
-			if err != nil {
-				return "", err
-			}
-
-			jsonData, err := json.Marshal(deserData)
-			if err != nil {
-				return "", err
-			}
-			return string(jsonData), nil
-		}
-	} else {
-		return string(data), nil
-	}
-
+	return d.decoders.decode(data, newAvroDecoder)
 }
 
-func (d *GoAvroAvroDeserializer) getSchema(schemaId int) (sradmin.Schema, error) {
-	var schema sradmin.Schema
-
-	switch msg := d.sra.GetSchemaById(schemaId).(type) {
-
-	case sradmin.GettingSchemaByIdMsg:
-		{
-			switch msg := msg.AwaitCompletion().(type) {
+// newAvroDecoder parses the schema once into a codec used for every payload.
+func newAvroDecoder(schema sradmin.Schema) (decoder, error) {
+	codec, err := goavro.NewCodec(schema.Schema)
+	if err != nil {
+		return nil, err
+	}
 
-			case sradmin.SchemaByIdReceived:
-				{
-					schema = msg.Schema
-				}
-			case sradmin.FailedToGetSchemaById:
-				{
-					return sradmin.Schema{}, msg.Err
-				}
-			}
+	return func(payload []byte) (string, error) {
+		deserData, _, err := codec.NativeFromBinary(payload)
+		if err != nil {
+			return "", err
 		}
 
-	case sradmin.SchemaByIdReceived:
-		{
-			schema = msg.Schema
+		jsonData, err := json.Marshal(deserData)
+		if err != nil {
+			return "", err
 		}
-	}
-
-	return schema, nil
-}
-
-func isAvroWithSchemaID(data []byte) (int, bool) {
-	if len(data) < 5 {
-		return -1, false
-	}
-
-	// Check the magic byte
-	if data[0] != 0x00 {
-		return -1, false
-	}
-
-	// Read the schema ID (4 bytes after the magic byte)
-	var schemaId int32
-	reader := bytes.NewReader(data[1:5])
-	if err := binary.Read(reader, binary.BigEndian, &schemaId); err != nil {
-		return -1, false
-	}
-
-	return int(schemaId), true
-}
-
-func NewAvroDeserializer(sra sradmin.SrAdmin) Deserializer {
-	return &GoAvroAvroDeserializer{sra: sra}
+		return string(jsonData), nil
+	}, nil
 }
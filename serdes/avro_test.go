@@ -24,7 +24,7 @@ func TestAvroDeserializer(t *testing.T) {
 `
 
 	t.Run("no data deserializes to empty string", func(t *testing.T) {
-		deserializer := NewAvroDeserializer(sradmin.NewMock())
+		deserializer := &GoAvroAvroDeserializer{decoders: newDecoderCache(sradmin.NewMock())}
 
 		res, err := deserializer.Deserialize(nil)
 
@@ -50,7 +50,7 @@ func TestAvroDeserializer(t *testing.T) {
 				},
 			}
 		}
-		deserializer := NewAvroDeserializer(sraMock)
+		deserializer := &GoAvroAvroDeserializer{decoders: newDecoderCache(sraMock)}
 
 		codec, err := goavro.NewCodec(schema)
 		if err != nil {
@@ -80,6 +80,25 @@ func TestAvroDeserializer(t *testing.T) {
 		assert.Equal(t, `{"Age":21,"Name":"John"}`, res)
 	})
 
+	t.Run("data without magic byte deserializes to plain string", func(t *testing.T) {
+		deserializer := &GoAvroAvroDeserializer{decoders: newDecoderCache(sradmin.NewMock())}
+
+		res, err := deserializer.Deserialize([]byte("order-123"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "order-123", res)
+	})
+
+	t.Run("data with magic byte without a schema registry deserializes to plain string", func(t *testing.T) {
+		deserializer := &GoAvroAvroDeserializer{decoders: newDecoderCache(nil)}
+
+		data := []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x02}
+		res, err := deserializer.Deserialize(data)
+
+		assert.Nil(t, err)
+		assert.Equal(t, string(data), res)
+	})
+
 	t.Run("deserialize failed", func(t *testing.T) {
 		t.Run("invalid schema", func(t *testing.T) {
 			sraMock := sradmin.NewMock()
@@ -94,7 +113,7 @@ func TestAvroDeserializer(t *testing.T) {
 					},
 				}
 			}
-			deserializer := NewAvroDeserializer(sraMock)
+			deserializer := &GoAvroAvroDeserializer{decoders: newDecoderCache(sraMock)}
 
 			codec, err := goavro.NewCodec(schema)
 			if err != nil {
@@ -0,0 +1,75 @@
+package serdes
+
+import (
+	"ktea/sradmin"
+	"sync"
+)
+
+// decoder decodes the payload following the schema ID into a readable string.
+type decoder func(payload []byte) (string, error)
+
+// decoderCache holds the decoders of schemas by schema ID, so a schema is fetched
+// from the registry and compiled only once. It is safe for concurrent use.
+type decoderCache struct {
+	sra      sradmin.SchemaFetcher
+	mu       sync.RWMutex
+	decoders map[int]decoder
+}
+
+// get returns the cached decoder of the schema, or creates one using newDecoder.
+// When the schema cannot be compiled a decoder returning that error is cached,
+// failing to fetch the schema is not cached so it is retried for the next record.
+func (c *decoderCache) get(
+	schemaId int,
+	newDecoder func(schema sradmin.Schema) (decoder, error),
+) (decoder, error) {
+	c.mu.RLock()
+	d, ok := c.decoders[schemaId]
+	c.mu.RUnlock()
+	if ok {
+		return d, nil
+	}
+
+	schema, err := getSchema(c.sra, schemaId)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err = newDecoder(schema)
+	if err != nil {
+		d = func([]byte) (string, error) {
+			return "", err
+		}
+	}
+
+	c.mu.Lock()
+	c.decoders[schemaId] = d
+	c.mu.Unlock()
+	return d, nil
+}
+
+// decode decodes data in the schema registry wire format, data without a schema ID is returned as is.
+func (c *decoderCache) decode(
+	data []byte,
+	newDecoder func(schema sradmin.Schema) (decoder, error),
+) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+
+	schemaId, hasSchemaId := withSchemaID(data)
+	// without a schema registry the schema cannot be looked up
+	if !hasSchemaId || c.sra == nil {
+		return string(data), nil
+	}
+
+	d, err := c.get(schemaId, newDecoder)
+	if err != nil {
+		return "", err
+	}
+	return d(data[5:])
+}
+
+func newDecoderCache(sra sradmin.SchemaFetcher) *decoderCache {
+	return &decoderCache{sra: sra, decoders: map[int]decoder{}}
+}
@@ -0,0 +1,82 @@
+package serdes
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/sradmin"
+	"sync"
+	"testing"
+)
+
+func TestDecoderCache(t *testing.T) {
+	jsonSchema := sradmin.Schema{
+		Id:     "1",
+		Type:   sradmin.JsonSchemaType,
+		Schema: `{"type": "object"}`,
+	}
+
+	t.Run("fetches and compiles a schema only once", func(t *testing.T) {
+		var fetches, compilations int
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			fetches++
+			return sradmin.SchemaByIdReceived{Schema: jsonSchema}
+		}
+		cache := newDecoderCache(sraMock)
+		newDecoder := func(schema sradmin.Schema) (decoder, error) {
+			compilations++
+			return newJsonSchemaDecoder(schema, true)
+		}
+
+		for i := 0; i < 100; i++ {
+			res, err := cache.decode(jsonSchemaWireFormat(t, `{"id":1}`), newDecoder)
+
+			assert.NoError(t, err)
+			assert.Equal(t, `{"id":1}`, res)
+		}
+
+		assert.Equal(t, 1, fetches)
+		assert.Equal(t, 1, compilations)
+	})
+
+	t.Run("failing to fetch a schema is retried", func(t *testing.T) {
+		var fetches int
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			fetches++
+			if fetches == 1 {
+				return sradmin.FailedToGetSchemaById{Err: errors.New("registry unavailable")}
+			}
+			return sradmin.SchemaByIdReceived{Schema: jsonSchema}
+		}
+		deserializer := NewDeserializer(sraMock, false)
+
+		_, err := deserializer.Deserialize(jsonSchemaWireFormat(t, `{"id":1}`))
+		assert.Error(t, err)
+
+		res, err := deserializer.Deserialize(jsonSchemaWireFormat(t, `{"id":1}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, res)
+	})
+
+	t.Run("safe for concurrent use", func(t *testing.T) {
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			return sradmin.SchemaByIdReceived{Schema: jsonSchema}
+		}
+		deserializer := NewDeserializer(sraMock, false)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := deserializer.Deserialize(jsonSchemaWireFormat(t, `{"id":1}`))
+				assert.NoError(t, err)
+				assert.Equal(t, `{"id":1}`, res)
+			}()
+		}
+		wg.Wait()
+	})
+}
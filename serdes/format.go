@@ -0,0 +1,250 @@
+package serdes
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protowire"
+	"ktea/config"
+	"ktea/sradmin"
+	"strconv"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Serializer interface {
+	Serialize(data string) ([]byte, error)
+}
+
+// deserializerFunc adapts a function to a Deserializer.
+type deserializerFunc func(data []byte) (string, error)
+
+func (f deserializerFunc) Deserialize(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	return f(data)
+}
+
+// serializerFunc adapts a function to a Serializer.
+type serializerFunc func(data string) ([]byte, error)
+
+func (f serializerFunc) Serialize(data string) ([]byte, error) {
+	if data == "" {
+		return nil, nil
+	}
+	return f(data)
+}
+
+// NewFormatDeserializer creates the Deserializer of the format. The schema registry is used by the
+// default, avro and protobuf formats and can be nil when there is none, validateJsonSchema applies
+// to JSON schema payloads read with the default format.
+func NewFormatDeserializer(format config.Format, sra sradmin.SchemaFetcher, validateJsonSchema bool) (Deserializer, error) {
+	switch format {
+	case config.DefaultFormat:
+		return NewDeserializer(sra, validateJsonSchema), nil
+	case config.StringFormat:
+		return deserializerFunc(func(data []byte) (string, error) {
+			return string(data), nil
+		}), nil
+	case config.JsonFormat:
+		return deserializerFunc(func(data []byte) (string, error) {
+			if !json.Valid(data) {
+				return "", errors.New("invalid JSON: " + string(data))
+			}
+			return string(data), nil
+		}), nil
+	case config.AvroFormat:
+		return &GoAvroAvroDeserializer{decoders: newDecoderCache(sra)}, nil
+	case config.ProtobufFormat:
+		registry := NewProtobufDeserializer(sra)
+		return deserializerFunc(func(data []byte) (string, error) {
+			if _, hasSchemaId := withSchemaID(data); hasSchemaId && sra != nil {
+				return registry.Deserialize(data)
+			}
+			return decodeRawProtobuf(data)
+		}), nil
+	case config.HexFormat:
+		return deserializerFunc(func(data []byte) (string, error) {
+			return hex.EncodeToString(data), nil
+		}), nil
+	case config.Base64Format:
+		return deserializerFunc(func(data []byte) (string, error) {
+			return base64.StdEncoding.EncodeToString(data), nil
+		}), nil
+	case config.LongFormat:
+		return deserializerFunc(func(data []byte) (string, error) {
+			if len(data) != 8 {
+				return "", fmt.Errorf("a long consists of 8 bytes, not %d", len(data))
+			}
+			return strconv.FormatInt(int64(binary.BigEndian.Uint64(data)), 10), nil
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// FormatDeserializers creates the Deserializer of a format once and shares it between reads,
+// so schemas are only fetched and compiled once. It is safe for concurrent use.
+type FormatDeserializers struct {
+	sra                sradmin.SchemaFetcher
+	validateJsonSchema bool
+	mu                 sync.Mutex
+	deserializers      map[config.Format]Deserializer
+}
+
+// Of returns the shared Deserializer of the format, see NewFormatDeserializer.
+func (d *FormatDeserializers) Of(format config.Format) (Deserializer, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if deserializer, ok := d.deserializers[format]; ok {
+		return deserializer, nil
+	}
+	deserializer, err := NewFormatDeserializer(format, d.sra, d.validateJsonSchema)
+	if err != nil {
+		return nil, err
+	}
+	d.deserializers[format] = deserializer
+	return deserializer, nil
+}
+
+// NewFormatDeserializers creates the shared deserializers of all formats, sra can be nil when
+// there is no schema registry.
+func NewFormatDeserializers(sra sradmin.SchemaFetcher, validateJsonSchema bool) *FormatDeserializers {
+	return &FormatDeserializers{
+		sra:                sra,
+		validateJsonSchema: validateJsonSchema,
+		deserializers:      map[config.Format]Deserializer{},
+	}
+}
+
+// NewFormatSerializer creates the Serializer of the format. Schema based formats cannot be
+// serialized, without a schema the data is published as is.
+func NewFormatSerializer(format config.Format) (Serializer, error) {
+	switch format {
+	case config.DefaultFormat, config.StringFormat:
+		return serializerFunc(func(data string) ([]byte, error) {
+			return []byte(data), nil
+		}), nil
+	case config.JsonFormat:
+		return serializerFunc(func(data string) ([]byte, error) {
+			if !json.Valid([]byte(data)) {
+				return nil, errors.New("invalid JSON")
+			}
+			return []byte(data), nil
+		}), nil
+	case config.AvroFormat, config.ProtobufFormat:
+		return serializerFunc(func(data string) ([]byte, error) {
+			return nil, fmt.Errorf("publishing %s is not supported", format)
+		}), nil
+	case config.HexFormat:
+		return serializerFunc(func(data string) ([]byte, error) {
+			return hex.DecodeString(data)
+		}), nil
+	case config.Base64Format:
+		return serializerFunc(func(data string) ([]byte, error) {
+			return base64.StdEncoding.DecodeString(data)
+		}), nil
+	case config.LongFormat:
+		return serializerFunc(func(data string) ([]byte, error) {
+			long, err := strconv.ParseInt(data, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a long", data)
+			}
+			return binary.BigEndian.AppendUint64(nil, uint64(long)), nil
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// decodeRawProtobuf decodes a protobuf message without its schema into JSON keyed by field number.
+// As the wire format does not tell nested messages, strings and bytes apart, length delimited fields
+// are decoded as a string when printable, then as a message when possible and otherwise as base64.
+func decodeRawProtobuf(data []byte) (string, error) {
+	fields, err := readRawProtobufFields(data)
+	if err != nil {
+		return "", fmt.Errorf("invalid protobuf message: %w", err)
+	}
+	jsonData, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(jsonData), nil
+}
+
+func readRawProtobufFields(data []byte) (map[string]any, error) {
+	fields := map[string]any{}
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		var value any
+		switch wireType {
+		case protowire.VarintType:
+			var v uint64
+			v, n = protowire.ConsumeVarint(data)
+			value = v
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(data)
+			value = v
+		case protowire.Fixed64Type:
+			var v uint64
+			v, n = protowire.ConsumeFixed64(data)
+			value = v
+		case protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(data)
+			value = rawProtobufBytes(v)
+		default:
+			n = protowire.ConsumeFieldValue(number, wireType, data)
+			value = nil
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		// repeated fields are collected in a list
+		key := strconv.Itoa(int(number))
+		switch existing := fields[key].(type) {
+		case nil:
+			fields[key] = value
+		case []any:
+			fields[key] = append(existing, value)
+		default:
+			fields[key] = []any{existing, value}
+		}
+	}
+	return fields, nil
+}
+
+func rawProtobufBytes(data []byte) any {
+	if isPrintable(data) {
+		return string(data)
+	}
+	if message, err := readRawProtobufFields(data); err == nil {
+		return message
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func isPrintable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
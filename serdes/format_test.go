@@ -0,0 +1,194 @@
+package serdes
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"ktea/config"
+	"ktea/sradmin"
+	"testing"
+)
+
+func TestFormatDeserializer(t *testing.T) {
+	deserialize := func(t *testing.T, format config.Format, data []byte) (string, error) {
+		deserializer, err := NewFormatDeserializer(format, nil, false)
+		assert.NoError(t, err)
+		return deserializer.Deserialize(data)
+	}
+
+	t.Run("no data deserializes to empty string", func(t *testing.T) {
+		for _, format := range config.Formats {
+			res, err := deserialize(t, format, nil)
+
+			assert.NoError(t, err)
+			assert.Empty(t, res)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		res, err := deserialize(t, config.StringFormat, []byte{0x00, 'a'})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "\x00a", res)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		res, err := deserialize(t, config.JsonFormat, []byte(`{"id":1}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":1}`, res)
+
+		_, err = deserialize(t, config.JsonFormat, []byte(`{"id":`))
+
+		assert.EqualError(t, err, `invalid JSON: {"id":`)
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		res, err := deserialize(t, config.HexFormat, []byte{0xca, 0xfe, 0x01})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "cafe01", res)
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		res, err := deserialize(t, config.Base64Format, []byte("ktea"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "a3RlYQ==", res)
+	})
+
+	t.Run("long", func(t *testing.T) {
+		res, err := deserialize(t, config.LongFormat, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "-2", res)
+
+		_, err = deserialize(t, config.LongFormat, []byte{0x01})
+
+		assert.EqualError(t, err, "a long consists of 8 bytes, not 1")
+	})
+
+	t.Run("protobuf without schema", func(t *testing.T) {
+		var line []byte
+		line = protowire.AppendTag(line, 1, protowire.BytesType)
+		line = protowire.AppendString(line, "sku-1")
+		var data []byte
+		data = protowire.AppendTag(data, 1, protowire.VarintType)
+		data = protowire.AppendVarint(data, 150)
+		data = protowire.AppendTag(data, 2, protowire.BytesType)
+		data = protowire.AppendBytes(data, line)
+		data = protowire.AppendTag(data, 2, protowire.BytesType)
+		data = protowire.AppendBytes(data, line)
+		data = protowire.AppendTag(data, 3, protowire.Fixed32Type)
+		data = protowire.AppendFixed32(data, 7)
+
+		res, err := deserialize(t, config.ProtobufFormat, data)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"1":150,"2":[{"1":"sku-1"},{"1":"sku-1"}],"3":7}`, res)
+
+		_, err = deserialize(t, config.ProtobufFormat, []byte{0x0a, 0x05})
+
+		assert.ErrorContains(t, err, "invalid protobuf message")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewFormatDeserializer("xml", nil, false)
+
+		assert.EqualError(t, err, `unknown format "xml"`)
+	})
+}
+
+func TestFormatDeserializers(t *testing.T) {
+	t.Run("fetches a schema only once across reads", func(t *testing.T) {
+		var fetches int
+		sraMock := sradmin.NewMock()
+		sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+			fetches++
+			return sradmin.SchemaByIdReceived{Schema: sradmin.Schema{
+				Id:     "1",
+				Type:   sradmin.JsonSchemaType,
+				Schema: `{"type": "object"}`,
+			}}
+		}
+		deserializers := NewFormatDeserializers(sraMock, false)
+
+		for i := 0; i < 3; i++ {
+			deserializer, err := deserializers.Of(config.DefaultFormat)
+			assert.NoError(t, err)
+
+			res, err := deserializer.Deserialize(jsonSchemaWireFormat(t, `{"id":1}`))
+			assert.NoError(t, err)
+			assert.Equal(t, `{"id":1}`, res)
+		}
+
+		assert.Equal(t, 1, fetches)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewFormatDeserializers(nil, false).Of("xml")
+
+		assert.EqualError(t, err, `unknown format "xml"`)
+	})
+}
+
+func TestFormatSerializer(t *testing.T) {
+	serialize := func(t *testing.T, format config.Format, data string) ([]byte, error) {
+		serializer, err := NewFormatSerializer(format)
+		assert.NoError(t, err)
+		return serializer.Serialize(data)
+	}
+
+	t.Run("empty data serializes to nil", func(t *testing.T) {
+		for _, format := range append(config.Formats, config.DefaultFormat) {
+			res, err := serialize(t, format, "")
+
+			assert.NoError(t, err)
+			assert.Nil(t, res)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		res, err := serialize(t, config.DefaultFormat, "order-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("order-1"), res)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		_, err := serialize(t, config.JsonFormat, `{"id":`)
+
+		assert.EqualError(t, err, "invalid JSON")
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		res, err := serialize(t, config.HexFormat, "cafe01")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xca, 0xfe, 0x01}, res)
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		res, err := serialize(t, config.Base64Format, "a3RlYQ==")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("ktea"), res)
+	})
+
+	t.Run("long", func(t *testing.T) {
+		res, err := serialize(t, config.LongFormat, "-2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}, res)
+
+		_, err = serialize(t, config.LongFormat, "two")
+
+		assert.EqualError(t, err, "'two' is not a long")
+	})
+
+	t.Run("schema based formats are not supported", func(t *testing.T) {
+		_, err := serialize(t, config.AvroFormat, "{}")
+
+		assert.EqualError(t, err, "publishing avro is not supported")
+	})
+}
@@ -0,0 +1,75 @@
+package serdes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"ktea/sradmin"
+	"strings"
+)
+
+// jsonSchemaFileName is the name under which a registered JSON schema is compiled,
+// its references are compiled under their own name.
+const jsonSchemaFileName = "schema.json"
+
+// JsonSchemaDeserializer deserializes payloads written with a JSON schema from the schema registry.
+type JsonSchemaDeserializer struct {
+	decoders *decoderCache
+	// validate the payload against the registered schema
+	validate bool
+}
+
+func (d *JsonSchemaDeserializer) Deserialize(data []byte) (string, error) {
+	return d.decoders.decode(data, func(schema sradmin.Schema) (decoder, error) {
+		return newJsonSchemaDecoder(schema, d.validate)
+	})
+}
+
+// newJsonSchemaDecoder returns the JSON payload following the schema ID,
+// optionally validated against the schema which is compiled once.
+func newJsonSchemaDecoder(schema sradmin.Schema, validate bool) (decoder, error) {
+	if !validate {
+		return func(payload []byte) (string, error) {
+			return string(payload), nil
+		}, nil
+	}
+
+	compiled, err := compileJsonSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(payload []byte) (string, error) {
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.UseNumber()
+		var document any
+		if err := decoder.Decode(&document); err != nil {
+			return "", err
+		}
+
+		if err := compiled.Validate(document); err != nil {
+			return "", fmt.Errorf("payload does not match schema %s: %w", schema.Id, err)
+		}
+		return string(payload), nil
+	}, nil
+}
+
+func compileJsonSchema(schema sradmin.Schema) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	for name, reference := range schema.References {
+		if err := compiler.AddResource(name, strings.NewReader(reference)); err != nil {
+			return nil, err
+		}
+	}
+	if err := compiler.AddResource(jsonSchemaFileName, strings.NewReader(schema.Schema)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(jsonSchemaFileName)
+}
+
+// NewJsonSchemaDeserializer creates a Deserializer for JSON schema payloads,
+// when validate is true payloads not matching their schema result in an error.
+func NewJsonSchemaDeserializer(sra sradmin.SchemaFetcher, validate bool) Deserializer {
+	return &JsonSchemaDeserializer{decoders: newDecoderCache(sra), validate: validate}
+}
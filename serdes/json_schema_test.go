@@ -0,0 +1,76 @@
+package serdes
+
+import (
+	"bytes"
+	"encoding/binary"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/sradmin"
+	"testing"
+)
+
+func TestJsonSchemaDeserializer(t *testing.T) {
+	sraMock := sradmin.NewMock()
+	sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+		return sradmin.SchemaByIdReceived{
+			Schema: sradmin.Schema{
+				Id:   "1",
+				Type: sradmin.JsonSchemaType,
+				Schema: `{
+  "type": "object",
+  "properties": {
+    "name": { "type": "string" },
+    "age": { "type": "integer" }
+  },
+  "required": ["name"]
+}`,
+			},
+		}
+	}
+
+	t.Run("strips the wire format header", func(t *testing.T) {
+		res, err := NewJsonSchemaDeserializer(sraMock, false).
+			Deserialize(jsonSchemaWireFormat(t, `{"age":21}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"age":21}`, res)
+	})
+
+	t.Run("validates the payload", func(t *testing.T) {
+		res, err := NewJsonSchemaDeserializer(sraMock, true).
+			Deserialize(jsonSchemaWireFormat(t, `{"name":"John","age":21}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"John","age":21}`, res)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, err := NewJsonSchemaDeserializer(sraMock, true).
+			Deserialize(jsonSchemaWireFormat(t, `{"age":"21"}`))
+
+		assert.ErrorContains(t, err, "payload does not match schema 1")
+	})
+
+	t.Run("schema registry deserializer dispatches on schema type", func(t *testing.T) {
+		res, err := NewDeserializer(sraMock, false).Deserialize(jsonSchemaWireFormat(t, `{"name":"Jane"}`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, `{"name":"Jane"}`, res)
+	})
+
+	t.Run("schema registry deserializer validates when configured", func(t *testing.T) {
+		_, err := NewDeserializer(sraMock, true).Deserialize(jsonSchemaWireFormat(t, `{"age":"21"}`))
+
+		assert.ErrorContains(t, err, "payload does not match schema 1")
+	})
+}
+
+func jsonSchemaWireFormat(t *testing.T, payload string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0x00)
+	if err := binary.Write(&buf, binary.BigEndian, int32(1)); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString(payload)
+	return buf.Bytes()
+}
@@ -0,0 +1,123 @@
+package serdes
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"ktea/sradmin"
+)
+
+// schemaFileName is the name under which a registered protobuf schema is compiled,
+// its references are compiled under the name they are imported with.
+const schemaFileName = "schema.proto"
+
+type ProtobufDeserializer struct {
+	decoders *decoderCache
+}
+
+func (d *ProtobufDeserializer) Deserialize(data []byte) (string, error) {
+	return d.decoders.decode(data, newProtobufDecoder)
+}
+
+// newProtobufDecoder compiles the schema once, the message type is resolved for every payload
+// as a schema can define multiple message types.
+func newProtobufDecoder(schema sradmin.Schema) (decoder, error) {
+	file, err := compileProtobufSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(payload []byte) (string, error) {
+		indexes, payload, err := readMessageIndexes(payload)
+		if err != nil {
+			return "", err
+		}
+
+		descriptor, err := messageDescriptor(file, indexes)
+		if err != nil {
+			return "", err
+		}
+
+		message := dynamicpb.NewMessage(descriptor)
+		if err := proto.Unmarshal(payload, message); err != nil {
+			return "", err
+		}
+
+		jsonData, err := protojson.Marshal(message)
+		if err != nil {
+			return "", err
+		}
+		return string(jsonData), nil
+	}, nil
+}
+
+func compileProtobufSchema(schema sradmin.Schema) (protoreflect.FileDescriptor, error) {
+	sources := map[string]string{schemaFileName: schema.Schema}
+	for name, reference := range schema.References {
+		sources[name] = reference
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+	files, err := compiler.Compile(context.Background(), schemaFileName)
+	if err != nil {
+		return nil, err
+	}
+	return files[0], nil
+}
+
+// readMessageIndexes reads the zigzag encoded varints that locate the message type within the schema.
+// A single 0 is a shortcut for the first message type.
+func readMessageIndexes(payload []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 {
+		return nil, nil, errors.New("unable to read protobuf message indexes")
+	}
+	payload = payload[n:]
+
+	if count == 0 {
+		return []int{0}, payload, nil
+	}
+	if count < 0 || int(count) > len(payload) {
+		return nil, nil, fmt.Errorf("invalid number of protobuf message indexes %d", count)
+	}
+
+	indexes := make([]int, count)
+	for i := range indexes {
+		index, n := binary.Varint(payload)
+		if n <= 0 {
+			return nil, nil, errors.New("unable to read protobuf message indexes")
+		}
+		indexes[i] = int(index)
+		payload = payload[n:]
+	}
+	return indexes, payload, nil
+}
+
+// messageDescriptor resolves the message indexes, the first being the index of a top level message
+// and every following one the index of a nested message.
+func messageDescriptor(file protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	var descriptor protoreflect.MessageDescriptor
+	messages := file.Messages()
+	for _, index := range indexes {
+		if index < 0 || index >= messages.Len() {
+			return nil, fmt.Errorf("message index %d not found in protobuf schema", index)
+		}
+		descriptor = messages.Get(index)
+		messages = descriptor.Messages()
+	}
+	return descriptor, nil
+}
+
+func NewProtobufDeserializer(sra sradmin.SchemaFetcher) Deserializer {
+	return &ProtobufDeserializer{decoders: newDecoderCache(sra)}
+}
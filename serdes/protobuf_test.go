@@ -0,0 +1,124 @@
+package serdes
+
+import (
+	"bytes"
+	"encoding/binary"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"ktea/sradmin"
+	"testing"
+)
+
+func TestProtobufDeserializer(t *testing.T) {
+	schema := sradmin.Schema{
+		Id:   "1",
+		Type: sradmin.ProtobufSchemaType,
+		Schema: `
+syntax = "proto3";
+package ktea.test;
+
+import "common.proto";
+
+message Person {
+  string name = 1;
+  int32 age = 2;
+}
+
+message Order {
+  message Line {
+    string sku = 1;
+    ktea.common.Money price = 2;
+  }
+  string id = 1;
+  repeated Line lines = 2;
+}
+`,
+		References: map[string]string{
+			"common.proto": `
+syntax = "proto3";
+package ktea.common;
+
+message Money {
+  int64 cents = 1;
+}
+`,
+		},
+	}
+	sraMock := sradmin.NewMock()
+	sraMock.GetSchemaByIdFunc = func(id int) tea.Msg {
+		return sradmin.SchemaByIdReceived{Schema: schema}
+	}
+
+	file, err := compileProtobufSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("deserialize first message", func(t *testing.T) {
+		person := dynamicpb.NewMessage(file.Messages().ByName("Person"))
+		person.Set(person.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("John"))
+		person.Set(person.Descriptor().Fields().ByName("age"), protoreflect.ValueOfInt32(21))
+
+		res, err := NewProtobufDeserializer(sraMock).Deserialize(protobufWireFormat(t, person, 0))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"John","age":21}`, res)
+	})
+
+	t.Run("deserialize nested message", func(t *testing.T) {
+		line := dynamicpb.NewMessage(file.Messages().ByName("Order").Messages().ByName("Line"))
+		line.Set(line.Descriptor().Fields().ByName("sku"), protoreflect.ValueOfString("A-1"))
+		money := dynamicpb.NewMessage(line.Descriptor().Fields().ByName("price").Message())
+		money.Set(money.Descriptor().Fields().ByName("cents"), protoreflect.ValueOfInt64(250))
+		line.Set(line.Descriptor().Fields().ByName("price"), protoreflect.ValueOfMessage(money))
+
+		res, err := NewProtobufDeserializer(sraMock).Deserialize(protobufWireFormat(t, line, 1, 0))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"sku":"A-1","price":{"cents":"250"}}`, res)
+	})
+
+	t.Run("unknown message index", func(t *testing.T) {
+		person := dynamicpb.NewMessage(file.Messages().ByName("Person"))
+
+		_, err := NewProtobufDeserializer(sraMock).Deserialize(protobufWireFormat(t, person, 5))
+
+		assert.EqualError(t, err, "message index 5 not found in protobuf schema")
+	})
+
+	t.Run("schema registry deserializer dispatches on schema type", func(t *testing.T) {
+		person := dynamicpb.NewMessage(file.Messages().ByName("Person"))
+		person.Set(person.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("Jane"))
+
+		res, err := NewDeserializer(sraMock, false).Deserialize(protobufWireFormat(t, person, 0))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name":"Jane"}`, res)
+	})
+}
+
+// protobufWireFormat serializes the message with the magic byte, schema ID 1 and message indexes.
+func protobufWireFormat(t *testing.T, message proto.Message, indexes ...int) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(0x00)
+	if err := binary.Write(&buf, binary.BigEndian, int32(1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(indexes) == 1 && indexes[0] == 0 {
+		buf.Write(binary.AppendVarint(nil, 0))
+	} else {
+		buf.Write(binary.AppendVarint(nil, int64(len(indexes))))
+		for _, index := range indexes {
+			buf.Write(binary.AppendVarint(nil, int64(index)))
+		}
+	}
+	data, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Write(data)
+	return buf.Bytes()
+}
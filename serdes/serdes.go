@@ -1,5 +1,103 @@
 package serdes
 
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"ktea/sradmin"
+)
+
 type Deserializer interface {
 	Deserialize(data []byte) (string, error)
 }
+
+// SchemaRegistryDeserializer deserializes data written in the schema registry wire format
+// (a magic byte followed by a schema ID) according to the type of the registered schema.
+// Other data is returned as is. Schemas are fetched and compiled once, after which
+// deserializing is a lookup followed by decoding the payload.
+type SchemaRegistryDeserializer struct {
+	decoders *decoderCache
+	// validate JSON payloads against their registered schema
+	validateJsonSchema bool
+}
+
+func (d *SchemaRegistryDeserializer) Deserialize(data []byte) (string, error) {
+	return d.decoders.decode(data, func(schema sradmin.Schema) (decoder, error) {
+		return newSchemaTypeDecoder(schema, d.validateJsonSchema)
+	})
+}
+
+func newSchemaTypeDecoder(schema sradmin.Schema, validateJsonSchema bool) (decoder, error) {
+	switch schema.Type {
+	case sradmin.ProtobufSchemaType:
+		return newProtobufDecoder(schema)
+	case sradmin.JsonSchemaType:
+		return newJsonSchemaDecoder(schema, validateJsonSchema)
+	case sradmin.AvroSchemaType, "":
+		return newAvroDecoder(schema)
+	default:
+		return nil, fmt.Errorf("unsupported schema type %s", schema.Type)
+	}
+}
+
+func getSchema(sra sradmin.SchemaFetcher, schemaId int) (sradmin.Schema, error) {
+	var schema sradmin.Schema
+
+	switch msg := sra.GetSchemaById(schemaId).(type) {
+
+	case sradmin.GettingSchemaByIdMsg:
+		{
+			switch msg := msg.AwaitCompletion().(type) {
+
+			case sradmin.SchemaByIdReceived:
+				{
+					schema = msg.Schema
+				}
+			case sradmin.FailedToGetSchemaById:
+				{
+					return sradmin.Schema{}, msg.Err
+				}
+			}
+		}
+
+	case sradmin.SchemaByIdReceived:
+		{
+			schema = msg.Schema
+		}
+
+	case sradmin.FailedToGetSchemaById:
+		{
+			return sradmin.Schema{}, msg.Err
+		}
+	}
+
+	return schema, nil
+}
+
+// withSchemaID returns the schema ID of data in the schema registry wire format.
+func withSchemaID(data []byte) (int, bool) {
+	if len(data) < 5 {
+		return -1, false
+	}
+
+	// Check the magic byte
+	if data[0] != 0x00 {
+		return -1, false
+	}
+
+	// Read the schema ID (4 bytes after the magic byte)
+	var schemaId int32
+	reader := bytes.NewReader(data[1:5])
+	if err := binary.Read(reader, binary.BigEndian, &schemaId); err != nil {
+		return -1, false
+	}
+
+	return int(schemaId), true
+}
+
+// NewDeserializer creates a Deserializer that selects the format based on the schema registry,
+// sra can be nil when there is no schema registry. When validateJsonSchema is true JSON schema
+// payloads not matching their schema result in an error.
+func NewDeserializer(sra sradmin.SchemaFetcher, validateJsonSchema bool) Deserializer {
+	return &SchemaRegistryDeserializer{decoders: newDecoderCache(sra), validateJsonSchema: validateJsonSchema}
+}
@@ -2,6 +2,7 @@ package sradmin
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riferrei/srclient"
 	"strconv"
 )
 
@@ -34,7 +35,10 @@ func (msg *GettingSchemaByIdMsg) AwaitCompletion() tea.Msg {
 }
 
 func (s *DefaultSrAdmin) GetSchemaById(id int) tea.Msg {
-	if schema, ok := s.schemaCache[id]; ok {
+	s.schemaCacheMu.RLock()
+	schema, ok := s.schemaCache[id]
+	s.schemaCacheMu.RUnlock()
+	if ok {
 		return SchemaByIdReceived{Schema: schema}
 	}
 	schemaChan := make(chan Schema)
@@ -53,10 +57,41 @@ func (s *DefaultSrAdmin) doGetSchemaById(id int, schemaChan chan Schema, errChan
 		errChan <- err
 		return
 	}
-	schemaChan <- Schema{
-		Id:      strconv.Itoa(schema.ID()),
-		Schema:  schema.Schema(),
-		Version: schema.Version(),
-		Err:     nil,
+	references := map[string]string{}
+	if err := s.resolveReferences(schema.References(), references); err != nil {
+		errChan <- err
+		return
+	}
+	fetched := Schema{
+		Id:         strconv.Itoa(schema.ID()),
+		Schema:     schema.Schema(),
+		Version:    schema.Version(),
+		Type:       toSchemaType(schema.SchemaType()),
+		References: references,
+		Err:        nil,
+	}
+
+	s.schemaCacheMu.Lock()
+	s.schemaCache[id] = fetched
+	s.schemaCacheMu.Unlock()
+
+	schemaChan <- fetched
+}
+
+// resolveReferences fetches the referenced schemas, and those they reference in turn.
+func (s *DefaultSrAdmin) resolveReferences(references []srclient.Reference, resolved map[string]string) error {
+	for _, reference := range references {
+		if _, ok := resolved[reference.Name]; ok {
+			continue
+		}
+		schema, err := s.client.GetSchemaByVersion(reference.Subject, reference.Version)
+		if err != nil {
+			return err
+		}
+		resolved[reference.Name] = schema.Schema()
+		if err := s.resolveReferences(schema.References(), resolved); err != nil {
+			return err
+		}
 	}
+	return nil
 }
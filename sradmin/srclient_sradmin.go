@@ -11,10 +11,12 @@ import (
 )
 
 type DefaultSrAdmin struct {
-	client      *srclient.SchemaRegistryClient
-	subjects    []Subject
-	mu          sync.RWMutex
-	schemaCache map[int]Schema
+	client   *srclient.SchemaRegistryClient
+	subjects []Subject
+	mu       sync.RWMutex
+	// schemaCache holds the schemas fetched by id, guarded by schemaCacheMu
+	schemaCache   map[int]Schema
+	schemaCacheMu sync.RWMutex
 }
 
 type SrAdmin interface {
@@ -81,7 +83,8 @@ func New(ktx *kontext.ProgramKtx) *DefaultSrAdmin {
 	registry := ktx.Config.ActiveCluster().SchemaRegistry
 	client := createHttpClient(registry)
 	return &DefaultSrAdmin{
-		client: srclient.NewSchemaRegistryClient(registry.Url, srclient.WithClient(client)),
+		client:      srclient.NewSchemaRegistryClient(registry.Url, srclient.WithClient(client)),
+		schemaCache: map[int]Schema{},
 	}
 }
 
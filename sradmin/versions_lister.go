@@ -2,14 +2,34 @@ package sradmin
 
 import (
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/riferrei/srclient"
 	"sync"
 )
 
+type SchemaType string
+
+const (
+	AvroSchemaType     SchemaType = "AVRO"
+	ProtobufSchemaType SchemaType = "PROTOBUF"
+	JsonSchemaType     SchemaType = "JSON"
+)
+
 type Schema struct {
 	Id      string
 	Schema  string
 	Version int
-	Err     error
+	// Type is the format of the schema, the registry omits it for Avro schemas
+	Type SchemaType
+	// References holds the schemas imported by this schema, by the name they are imported with
+	References map[string]string
+	Err        error
+}
+
+func toSchemaType(schemaType *srclient.SchemaType) SchemaType {
+	if schemaType == nil {
+		return AvroSchemaType
+	}
+	return SchemaType(*schemaType)
 }
 
 type SchemasListed struct {
@@ -54,6 +74,7 @@ func (s *DefaultSrAdmin) ListVersions(subject string, versions []int) tea.Msg {
 					Id:      schema.Schema(),
 					Schema:  schema.Schema(),
 					Version: version,
+					Type:    toSchemaType(schema.SchemaType()),
 				}
 			} else {
 				schemaChan <- Schema{
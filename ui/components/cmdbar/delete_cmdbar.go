@@ -91,6 +91,12 @@ func (s *DeleteCmdBar[T]) Delete(d T) {
 	s.deleteValue = d
 }
 
+// Activate asks to confirm the deletion of d, for when deleting is not triggered by F2.
+func (s *DeleteCmdBar[T]) Activate(d T) {
+	s.deleteValue = d
+	s.active = true
+}
+
 func newDeleteConfirm() *huh.Confirm {
 	return huh.NewConfirm().
 		Inline(true).
@@ -65,4 +65,19 @@ func TestDeleteCmdBar(t *testing.T) {
 		assert.Equal(t, AssertDeletedMsg{"deleteMe"}, cmd())
 	})
 
+	t.Run("Activate asks to confirm deleting the value", func(t *testing.T) {
+		var deleteFunc DeleteFunc[string] = func(s string) tea.Cmd {
+			return func() tea.Msg {
+				return AssertDeletedMsg{deleteValue: s}
+			}
+		}
+		cmdBar := NewDeleteCmdBar[string](nil, deleteFunc, nil)
+
+		cmdBar.Activate("deleteMe")
+
+		assert.True(t, cmdBar.IsFocussed())
+		cmdBar.Update(keys.Key('d'))
+		_, _, cmd := cmdBar.Update(keys.Key(tea.KeyEnter))
+		assert.Equal(t, AssertDeletedMsg{"deleteMe"}, cmd())
+	})
 }
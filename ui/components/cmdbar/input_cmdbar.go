@@ -0,0 +1,92 @@
+package cmdbar
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/statusbar"
+)
+
+// InputCmdBar asks for a single value, which is parsed into a message on enter.
+type InputCmdBar struct {
+	input  *huh.Input
+	active bool
+	value  string
+	err    error
+	parse  func(value string) (tea.Msg, error)
+}
+
+func (c *InputCmdBar) IsFocussed() bool {
+	return c.active
+}
+
+func (c *InputCmdBar) Shortcuts() []statusbar.Shortcut {
+	return []statusbar.Shortcut{
+		{Name: "Confirm", Keybinding: "enter"},
+		{Name: "Cancel", Keybinding: "esc"},
+	}
+}
+
+func (c *InputCmdBar) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	if !c.active {
+		return ""
+	}
+	view := c.input.View()
+	if c.err != nil {
+		view += "\n" + styles.FG(styles.ColorRed).Render(c.err.Error())
+	}
+	style := styles.CmdBarWithWidth(ktx.WindowWidth - BorderedPadding).
+		BorderForeground(lipgloss.Color(styles.ColorFocusBorder))
+	return renderer.RenderWithStyle(view, style)
+}
+
+func (c *InputCmdBar) Update(msg tea.Msg) (bool, tea.Msg, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !c.active {
+		return c.active, msg, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		c.active = false
+		return false, nil, nil
+	case "enter":
+		parsed, err := c.parse(c.value)
+		if err != nil {
+			c.err = err
+			return true, nil, nil
+		}
+		c.active = false
+		c.input.Blur()
+		return false, nil, ui.PublishMsg(parsed)
+	default:
+		input, cmd := c.input.Update(keyMsg)
+		if i, ok := input.(*huh.Input); ok {
+			c.input = i
+		}
+		c.err = nil
+		return true, nil, cmd
+	}
+}
+
+// Activate shows the input prefilled with value, parse turns the entered value into the message to publish.
+func (c *InputCmdBar) Activate(title, description, value string, parse func(string) (tea.Msg, error)) {
+	c.value = value
+	c.err = nil
+	c.parse = parse
+	c.input = huh.NewInput().
+		Title(title + " ").
+		Description("(" + description + ") ").
+		Inline(true).
+		Value(&c.value)
+	c.input.Init()
+	c.input.Focus()
+	c.active = true
+}
+
+func NewInputCmdBar() *InputCmdBar {
+	return &InputCmdBar{}
+}
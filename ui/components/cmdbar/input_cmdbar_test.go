@@ -0,0 +1,66 @@
+package cmdbar
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"testing"
+)
+
+type ParsedMsg struct {
+	value string
+}
+
+func TestInputCmdBar(t *testing.T) {
+	parse := func(value string) (tea.Msg, error) {
+		if value == "" {
+			return nil, errors.New("value cannot be empty")
+		}
+		return ParsedMsg{value}, nil
+	}
+
+	t.Run("Inactive until activated", func(t *testing.T) {
+		cmdBar := NewInputCmdBar()
+
+		active, msg, cmd := cmdBar.Update(keys.Key('a'))
+
+		assert.False(t, active)
+		assert.Equal(t, keys.Key('a'), msg)
+		assert.Nil(t, cmd)
+	})
+
+	t.Run("Publish parsed value on enter", func(t *testing.T) {
+		cmdBar := NewInputCmdBar()
+		cmdBar.Activate("Value", "a value", "a", parse)
+
+		cmdBar.Update(keys.Key('b'))
+		active, _, cmd := cmdBar.Update(keys.Key(tea.KeyEnter))
+
+		assert.False(t, active)
+		assert.Equal(t, ParsedMsg{"ab"}, cmd())
+	})
+
+	t.Run("Show error when value cannot be parsed", func(t *testing.T) {
+		cmdBar := NewInputCmdBar()
+		cmdBar.Activate("Value", "a value", "", parse)
+
+		active, _, cmd := cmdBar.Update(keys.Key(tea.KeyEnter))
+
+		assert.True(t, active)
+		assert.Nil(t, cmd)
+		assert.Contains(t, cmdBar.View(ui.NewTestKontext(), ui.TestRenderer), "value cannot be empty")
+	})
+
+	t.Run("esc cancels", func(t *testing.T) {
+		cmdBar := NewInputCmdBar()
+		cmdBar.Activate("Value", "a value", "a", parse)
+
+		active, _, cmd := cmdBar.Update(keys.Key(tea.KeyEsc))
+
+		assert.False(t, active)
+		assert.Nil(t, cmd)
+		assert.Empty(t, cmdBar.View(ui.NewTestKontext(), ui.TestRenderer))
+	})
+}
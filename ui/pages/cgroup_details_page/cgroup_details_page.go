@@ -0,0 +1,159 @@
+package cgroup_details_page
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Model struct {
+	table table.Model
+	rows  []table.Row
+	group *kadmin.ConsumerGroup
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	var views []string
+	views = append(views, renderer.Render(m.summaryView()))
+
+	width := float64(ktx.WindowWidth - 9)
+	m.table.SetHeight(ktx.AvailableHeight - 2)
+	m.table.SetWidth(ktx.WindowWidth - 2)
+	m.table.SetColumns([]table.Column{
+		{"Member", int(width * 0.4)},
+		{"Host", int(width * 0.2)},
+		{"Topic", int(width * 0.25)},
+		{"Partitions", int(width * 0.15)},
+	})
+	m.table.SetRows(m.rows)
+	views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Focus))
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) summaryView() string {
+	coordinator := "unknown"
+	if c := m.group.Coordinator; c != nil {
+		coordinator = fmt.Sprintf("broker %d (%s)", c.ID, c.Address)
+	}
+	protocol := m.group.ProtocolType
+	if m.group.Protocol != "" {
+		protocol += "/" + m.group.Protocol
+	}
+	if protocol == "" {
+		protocol = "none"
+	}
+	return fmt.Sprintf(
+		" State %s, %d members, protocol %s, coordinator %s",
+		stateView(m.group.State),
+		len(m.group.Members),
+		protocol,
+		coordinator,
+	)
+}
+
+func stateView(state string) string {
+	switch state {
+	case "":
+		return "Unknown"
+	case "Stable":
+		return styles.FG(styles.ColorGreen).Render(state)
+	case "PreparingRebalance", "CompletingRebalance":
+		return styles.FG(styles.ColorYellow).Render(state)
+	case "Dead":
+		return styles.FG(styles.ColorRed).Render(state)
+	}
+	return state
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			return ui.PublishMsg(nav.LoadCGroupsPageMsg{})
+		}
+	}
+
+	t, cmd := m.table.Update(msg)
+	m.table = t
+	return cmd
+}
+
+// createRows lists a row per topic a member is assigned to, or a single row when it has no assignment.
+func (m *Model) createRows() []table.Row {
+	members := make([]kadmin.GroupMember, len(m.group.Members))
+	copy(members, m.group.Members)
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].MemberId < members[j].MemberId
+	})
+
+	var rows []table.Row
+	for _, member := range members {
+		if len(member.Assignment) == 0 {
+			rows = append(rows, table.Row{member.MemberId, member.ClientHost, "-", "-"})
+			continue
+		}
+
+		var topics []string
+		for topic := range member.Assignment {
+			topics = append(topics, topic)
+		}
+		sort.Strings(topics)
+
+		for _, topic := range topics {
+			rows = append(rows, table.Row{
+				member.MemberId,
+				member.ClientHost,
+				topic,
+				formatPartitions(member.Assignment[topic]),
+			})
+		}
+	}
+	return rows
+}
+
+func formatPartitions(partitions []int32) string {
+	sorted := make([]int, len(partitions))
+	for i, p := range partitions {
+		sorted[i] = int(p)
+	}
+	sort.Ints(sorted)
+
+	formatted := make([]string, len(sorted))
+	for i, p := range sorted {
+		formatted[i] = strconv.Itoa(p)
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	return []statusbar.Shortcut{
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Consumer Groups / " + m.group.Name + " / Details"
+}
+
+func New(group *kadmin.ConsumerGroup) *Model {
+	m := &Model{
+		group: group,
+		table: table.New(
+			table.WithFocused(true),
+			table.WithStyles(styles.Table.Styles),
+		),
+	}
+	m.rows = m.createRows()
+	return m
+}
@@ -0,0 +1,76 @@
+package cgroup_details_page
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+func group() *kadmin.ConsumerGroup {
+	return &kadmin.ConsumerGroup{
+		Name:         "group1",
+		State:        "Stable",
+		ProtocolType: "consumer",
+		Protocol:     "range",
+		Coordinator:  &kadmin.Broker{ID: 2, Address: "broker-2:9092"},
+		Members: []kadmin.GroupMember{
+			{
+				MemberId:   "consumer-2",
+				ClientHost: "/10.0.0.2",
+				Assignment: map[string][]int32{"topic1": {3, 1}},
+			},
+			{
+				MemberId:   "consumer-1",
+				ClientHost: "/10.0.0.1",
+				Assignment: map[string][]int32{"topic2": {0}, "topic1": {0, 2}},
+			},
+			{
+				MemberId:   "consumer-3",
+				ClientHost: "/10.0.0.3",
+			},
+		},
+	}
+}
+
+func TestCGroupDetailsPage(t *testing.T) {
+	t.Run("esc goes back to the consumer groups", func(t *testing.T) {
+		m := New(group())
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadCGroupsPageMsg{}, cmd())
+	})
+
+	t.Run("show state, protocol and coordinator", func(t *testing.T) {
+		m := New(group())
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "State Stable, 3 members, protocol consumer/range, coordinator broker 2 (broker-2:9092)")
+	})
+
+	t.Run("show unknown coordinator", func(t *testing.T) {
+		g := group()
+		g.Coordinator = nil
+		m := New(g)
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "coordinator unknown")
+	})
+
+	t.Run("show partitions assigned per member and topic", func(t *testing.T) {
+		m := New(group())
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Regexp(t, `consumer-1\s+/10.0.0.1\s+topic1\s+0, 2`, render)
+		assert.Regexp(t, `consumer-1\s+/10.0.0.1\s+topic2\s+0`, render)
+		assert.Regexp(t, `consumer-2\s+/10.0.0.2\s+topic1\s+1, 3`, render)
+		assert.Regexp(t, `consumer-3\s+/10.0.0.3\s+-\s+-`, render)
+	})
+}
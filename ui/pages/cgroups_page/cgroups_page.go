@@ -34,8 +34,10 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	m.table.SetHeight(ktx.AvailableHeight - 2)
 	m.table.SetWidth(ktx.WindowWidth - 2)
 	m.table.SetColumns([]table.Column{
-		{"Consumer Group", int(float64(ktx.WindowWidth-5) * 0.7)},
-		{"Members", int(float64(ktx.WindowWidth-5) * 0.3)},
+		{"Consumer Group", int(float64(ktx.WindowWidth-9) * 0.5)},
+		{"State", int(float64(ktx.WindowWidth-9) * 0.2)},
+		{"Members", int(float64(ktx.WindowWidth-9) * 0.1)},
+		{"Total Lag", int(float64(ktx.WindowWidth-9) * 0.2)},
 	})
 	m.table.SetRows(m.rows)
 
@@ -62,6 +64,12 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 				// TODO ignore enter when there are no groups loaded
 				return ui.PublishMsg(nav.LoadCGroupTopicsPageMsg{GroupName: *m.SelectedCGroup()})
 			}
+		case "f3":
+			if !m.cmdBar.IsFocussed() {
+				if group := m.selectedGroup(); group != nil {
+					return ui.PublishMsg(nav.LoadCGroupDetailsPageMsg{Group: group})
+				}
+			}
 		case "f5":
 			return m.lister.ListCGroups
 		}
@@ -115,12 +123,21 @@ func (m *Model) apppendGroupToRows(rows []table.Row, group *kadmin.ConsumerGroup
 		rows,
 		table.Row{
 			group.Name,
+			group.State,
 			strconv.Itoa(len(group.Members)),
+			totalLag(group),
 		},
 	)
 	return rows
 }
 
+func totalLag(group *kadmin.ConsumerGroup) string {
+	if group.TotalLag == kadmin.UnknownLag {
+		return "-"
+	}
+	return strconv.FormatInt(group.TotalLag, 10)
+}
+
 func (m *Model) SelectedCGroup() *string {
 	selectedRow := m.table.SelectedRow()
 	var selectedTopic string
@@ -130,10 +147,21 @@ func (m *Model) SelectedCGroup() *string {
 	return &selectedTopic
 }
 
+func (m *Model) selectedGroup() *kadmin.ConsumerGroup {
+	name := *m.SelectedCGroup()
+	for _, group := range m.groups {
+		if group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
 func (m *Model) Shortcuts() []statusbar.Shortcut {
 	return []statusbar.Shortcut{
 		{"Search", "/"},
 		{"View", "enter"},
+		{"Details", "F3"},
 		{"Refresh", "F5"},
 	}
 }
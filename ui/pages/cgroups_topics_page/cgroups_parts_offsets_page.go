@@ -21,6 +21,12 @@ const (
 	offsetFocus tableFocus = 1
 )
 
+// lags from which a partition is considered to be falling behind or to be critically behind
+const (
+	lagWarning  = 1_000
+	lagCritical = 10_000
+)
+
 type tableFocus int
 
 type Model struct {
@@ -31,6 +37,8 @@ type Model struct {
 	offsetRows        []table.Row
 	groupName         string
 	topicByPartOffset map[string][]partOffset
+	topicLags         map[string]int64
+	sortByLag         bool
 	cmdBar            *CmdBar
 }
 
@@ -57,14 +65,17 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	m.topicsTable.SetHeight(ktx.AvailableHeight - 1)
 	m.topicsTable.SetWidth(halfWidth - 2)
 	m.topicsTable.SetColumns([]table.Column{
-		{"Topic Name", int(float64(halfWidth - 4))},
+		{"Topic Name", int(float64(halfWidth-6) * 0.7)},
+		{"Lag", int(float64(halfWidth-6) * 0.3)},
 	})
 	m.topicsTable.SetRows(m.topicsRows)
 
 	m.offsetsTable.SetHeight(ktx.AvailableHeight - 1)
 	m.offsetsTable.SetColumns([]table.Column{
-		{"Partition", int(float64(halfWidth-6) * 0.5)},
-		{"Offset", int(float64(halfWidth-5) * 0.5)},
+		{"Partition", int(float64(halfWidth-10) * 0.2)},
+		{"Offset", int(float64(halfWidth-10) * 0.25)},
+		{"High Watermark", int(float64(halfWidth-10) * 0.3)},
+		{"Lag", int(float64(halfWidth-10) * 0.25)},
 	})
 	m.offsetsTable.SetRows(m.offsetRows)
 
@@ -88,8 +99,10 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 }
 
 type partOffset struct {
-	partition string
-	offset    string
+	partition     int32
+	offset        int64
+	highWatermark int64
+	lag           int64
 }
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
@@ -100,6 +113,13 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		switch msg.String() {
 		case "esc":
 			return ui.PublishMsg(nav.LoadCGroupsPageMsg{})
+		case "s":
+			m.sortByLag = !m.sortByLag
+			m.sortTopicRows()
+		case "r":
+			if m.topicByPartOffset != nil {
+				return ui.PublishMsg(m.resetOffsetsPageMsg())
+			}
 		}
 	case kadmin.OffsetListingStartedMsg:
 		cmds = append(
@@ -142,40 +162,94 @@ func (m *Model) recreateOffsetRows() {
 	if selectedTopic == "" {
 		selectedTopic = m.topicsRows[0][0]
 	}
+	partOffsets := slices.Clone(m.topicByPartOffset[selectedTopic])
+	sort.SliceStable(partOffsets, func(i, j int) bool {
+		if m.sortByLag && partOffsets[i].lag != partOffsets[j].lag {
+			return partOffsets[i].lag > partOffsets[j].lag
+		}
+		return partOffsets[i].partition < partOffsets[j].partition
+	})
 	m.offsetRows = []table.Row{}
-	for _, partOffset := range m.topicByPartOffset[selectedTopic] {
+	for _, partOffset := range partOffsets {
+		highWatermark := "-"
+		if partOffset.highWatermark != kadmin.UnknownLag {
+			highWatermark = strconv.FormatInt(partOffset.highWatermark, 10)
+		}
 		m.offsetRows = append(m.offsetRows, table.Row{
-			partOffset.partition,
-			partOffset.offset,
+			strconv.FormatInt(int64(partOffset.partition), 10),
+			strconv.FormatInt(partOffset.offset, 10),
+			highWatermark,
+			formatLag(partOffset.lag),
 		})
 	}
-	sort.SliceStable(m.offsetRows, func(i, j int) bool {
-		return m.offsetRows[i][0] < m.offsetRows[j][0]
-	})
+}
+
+// formatLag colours the lag by its severity.
+func formatLag(lag int64) string {
+	switch {
+	case lag == kadmin.UnknownLag:
+		return "-"
+	case lag >= lagCritical:
+		return styles.FG(styles.ColorRed).Render(strconv.FormatInt(lag, 10))
+	case lag >= lagWarning:
+		return styles.FG(styles.ColorYellow).Render(strconv.FormatInt(lag, 10))
+	default:
+		return styles.FG(styles.ColorGreen).Render(strconv.FormatInt(lag, 10))
+	}
 }
 
 func (m *Model) handleOffsetListed(msg kadmin.OffsetListedMsg) {
 	var topics []string
 	m.topicByPartOffset = make(map[string][]partOffset)
+	offsetsByTopic := make(map[string][]kadmin.TopicPartitionOffset)
 	for _, offset := range msg.Offsets {
 		if !slices.Contains(topics, offset.Topic) {
 			topics = append(topics, offset.Topic)
 		}
 		partOffset := partOffset{
-			partition: strconv.FormatInt(int64(offset.Partition), 10),
-			offset:    strconv.FormatInt(offset.Offset, 10),
+			partition:     offset.Partition,
+			offset:        offset.Offset,
+			highWatermark: offset.HighWatermark,
+			lag:           offset.Lag,
 		}
 		m.topicByPartOffset[offset.Topic] = append(m.topicByPartOffset[offset.Topic], partOffset)
+		offsetsByTopic[offset.Topic] = append(offsetsByTopic[offset.Topic], offset)
 	}
+	m.topicLags = make(map[string]int64)
 	m.topicsRows = []table.Row{}
 	for _, topic := range topics {
-		m.topicsRows = append(m.topicsRows, table.Row{topic})
+		m.topicLags[topic] = kadmin.TotalLag(offsetsByTopic[topic])
+		m.topicsRows = append(m.topicsRows, table.Row{topic, formatLag(m.topicLags[topic])})
 	}
+	m.sortTopicRows()
+}
+
+func (m *Model) sortTopicRows() {
 	sort.SliceStable(m.topicsRows, func(i, j int) bool {
-		return m.topicsRows[i][0] < m.topicsRows[j][0]
+		iTopic, jTopic := m.topicsRows[i][0], m.topicsRows[j][0]
+		if m.sortByLag && m.topicLags[iTopic] != m.topicLags[jTopic] {
+			return m.topicLags[iTopic] > m.topicLags[jTopic]
+		}
+		return iTopic < jTopic
 	})
 }
 
+func (m *Model) resetOffsetsPageMsg() nav.LoadResetOffsetsPageMsg {
+	msg := nav.LoadResetOffsetsPageMsg{GroupName: m.groupName}
+	if len(m.topicsRows) == 0 {
+		return msg
+	}
+	msg.Topic = m.selectedRow()
+	if msg.Topic == "" {
+		msg.Topic = m.topicsRows[0][0]
+	}
+	for _, partOffset := range m.topicByPartOffset[msg.Topic] {
+		msg.Partitions = append(msg.Partitions, partOffset.partition)
+	}
+	slices.Sort(msg.Partitions)
+	return msg
+}
+
 func (m *Model) selectedRow() string {
 	row := m.topicsTable.SelectedRow()
 	if row == nil {
@@ -185,7 +259,13 @@ func (m *Model) selectedRow() string {
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
+	sortShortcut := statusbar.Shortcut{Name: "Sort by Lag", Keybinding: "s"}
+	if m.sortByLag {
+		sortShortcut = statusbar.Shortcut{Name: "Sort by Name", Keybinding: "s"}
+	}
 	return []statusbar.Shortcut{
+		sortShortcut,
+		{"Reset Offsets", "r"},
 		{"Go Back", "esc"},
 	}
 }
@@ -1,9 +1,13 @@
 package cgroups_topics_page
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"ktea/kadmin"
+	"ktea/tests/keys"
 	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"strings"
 	"testing"
 )
 
@@ -71,4 +75,72 @@ func TestCgroupPartsOffsetsPage(t *testing.T) {
 		assert.Contains(t, view, "👀 No Committed Offsets Found")
 	})
 
+	t.Run("Show lag per partition and per topic", func(t *testing.T) {
+		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+
+		model.Update(kadmin.OffsetListedMsg{
+			Offsets: []kadmin.TopicPartitionOffset{
+				{Topic: "topic-1", Partition: 0, Offset: 10, HighWatermark: 15, Lag: 5},
+				{Topic: "topic-1", Partition: 1, Offset: 11, HighWatermark: 5011, Lag: 5000},
+				{Topic: "topic-1", Partition: 2, Offset: 12, HighWatermark: kadmin.UnknownLag, Lag: kadmin.UnknownLag},
+			},
+		})
+
+		view := model.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Regexp(t, `topic-1\s+5005`, view)
+		assert.Regexp(t, `0\s+10\s+15\s+5\s`, view)
+		assert.Regexp(t, `1\s+11\s+5011\s+5000`, view)
+		assert.Regexp(t, `2\s+12\s+-\s+-`, view)
+	})
+
+	t.Run("Sort by lag", func(t *testing.T) {
+		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model.Update(kadmin.OffsetListedMsg{
+			Offsets: []kadmin.TopicPartitionOffset{
+				{Topic: "topic-1", Partition: 0, Offset: 10, HighWatermark: 15, Lag: 5},
+				{Topic: "topic-1", Partition: 1, Offset: 10, HighWatermark: 20010, Lag: 20000},
+				{Topic: "topic-2", Partition: 0, Offset: 10, HighWatermark: 30010, Lag: 30000},
+			},
+		})
+
+		view := model.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Less(t, strings.Index(view, "topic-1"), strings.Index(view, "topic-2"))
+		assert.Less(t, strings.Index(view, "15"), strings.Index(view, "20000"))
+
+		model.Update(keys.Key('s'))
+
+		view = model.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Less(t, strings.Index(view, "topic-2"), strings.Index(view, "topic-1"))
+
+		model.Update(keys.Key(tea.KeyDown))
+
+		view = model.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Less(t, strings.Index(view, "20000"), strings.Index(view, "15"))
+		assert.Equal(t, "Sort by Name", model.Shortcuts()[0].Name)
+
+		model.Update(keys.Key('s'))
+
+		assert.Equal(t, "Sort by Lag", model.Shortcuts()[0].Name)
+	})
+
+	t.Run("r loads the reset offsets page for the selected topic", func(t *testing.T) {
+		model, _ := New(kadmin.NewMockKadmin(), "test-group")
+		model.Update(kadmin.OffsetListedMsg{
+			Offsets: []kadmin.TopicPartitionOffset{
+				{Topic: "topic-1", Partition: 1, Offset: 10},
+				{Topic: "topic-1", Partition: 0, Offset: 10},
+				{Topic: "topic-2", Partition: 0, Offset: 10},
+			},
+		})
+		model.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		cmd := model.Update(keys.Key('r'))
+
+		assert.Equal(t, nav.LoadResetOffsetsPageMsg{
+			GroupName:  "test-group",
+			Topic:      "topic-1",
+			Partitions: []int32{0, 1},
+		}, cmd())
+	})
 }
@@ -25,6 +25,7 @@ const (
 	UPDATE_FAILED    state = 5
 	UPDATE_SUCCEEDED state = 6
 	LOADING          state = 7
+	VALIDATING       state = 8
 )
 
 type CmdBarModel struct {
@@ -36,12 +37,15 @@ type CmdBarModel struct {
 	topicConfigLister kadmin.TopicConfigLister
 	topic             string
 	updated           bool
+	operation         kadmin.ConfigOperation
 }
 
 type SelectedTopicConfig struct {
 	Topic       string
 	ConfigKey   string
 	ConfigValue string
+	ReadOnly    bool
+	Override    bool
 }
 
 type HideBarMsg struct{}
@@ -53,7 +57,7 @@ func (m *CmdBarModel) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) strin
 		views = append(views, renderer.RenderWithStyle(m.searchInput.View(), styles.CmdBar.Width(ktx.WindowWidth-2)))
 	} else if m.state == EDITING {
 		views = append(views, renderer.RenderWithStyle(m.editInput.View(), styles.CmdBar.Width(ktx.WindowWidth-2)))
-	} else if m.state == UPDATING || m.state == UPDATE_FAILED || m.state == UPDATE_SUCCEEDED || m.state == LOADING {
+	} else if m.state == VALIDATING || m.state == UPDATING || m.state == UPDATE_FAILED || m.state == UPDATE_SUCCEEDED || m.state == LOADING {
 		views = append(views, renderer.RenderWithStyle(m.notifier.View(ktx, renderer), styles.CmdBar.Width(ktx.WindowWidth-2)))
 	}
 
@@ -81,10 +85,16 @@ func (m *CmdBarModel) Update(msg tea.Msg, stc SelectedTopicConfig) (tea.Msg, tea
 			}
 			return nil, nil
 		} else if msg.String() == "e" && isEditable(m) {
-			m.state = EDITING
-			m.editInput = newEditInput(stc.ConfigValue)
-			m.editInput.Focus()
+			m.edit(stc, kadmin.SetConfig, stc.ConfigValue, "New config value")
 			return nil, nil
+		} else if msg.String() == "a" && isEditable(m) {
+			m.edit(stc, kadmin.AppendConfig, "", "Value to append")
+			return nil, nil
+		} else if msg.String() == "s" && isEditable(m) {
+			m.edit(stc, kadmin.SubtractConfig, "", "Value to remove")
+			return nil, nil
+		} else if msg.String() == "r" && isEditable(m) {
+			return nil, m.resetToDefault(stc)
 		} else if msg.String() == "enter" {
 			if m.state == SEARCHING {
 				if m.GetSearchTerm() == "" {
@@ -93,15 +103,17 @@ func (m *CmdBarModel) Update(msg tea.Msg, stc SelectedTopicConfig) (tea.Msg, tea
 					m.state = SEARCHED
 				}
 			} else if m.state == EDITING {
-				m.state = UPDATING
+				m.state = VALIDATING
+				toUpdate := kadmin.TopicConfigToUpdate{
+					Topic:     stc.Topic,
+					Key:       stc.ConfigKey,
+					Value:     m.editInput.GetValue().(string),
+					Operation: m.operation,
+				}
 				return nil, tea.Batch(
-					m.notifier.SpinWithRocketMsg("Updating Topic Config"),
+					m.notifier.SpinWithLoadingMsg("Validating Topic Config"),
 					func() tea.Msg {
-						return m.configUpdater.UpdateConfig(kadmin.TopicConfigToUpdate{
-							Topic: stc.Topic,
-							Key:   stc.ConfigKey,
-							Value: m.editInput.GetValue().(string),
-						})
+						return m.configUpdater.ValidateConfig(toUpdate)
 					},
 				)
 			}
@@ -137,6 +149,23 @@ func (m *CmdBarModel) Update(msg tea.Msg, stc SelectedTopicConfig) (tea.Msg, tea
 			m.notifier.Idle()
 		}
 		return nil, nil
+	case kadmin.TopicConfigValidatedMsg:
+		// only apply the update once the brokers accepted it in a dry run
+		m.state = UPDATING
+		return nil, tea.Batch(
+			m.notifier.SpinWithRocketMsg("Updating Topic Config"),
+			func() tea.Msg {
+				return m.configUpdater.UpdateConfig(msg.Config)
+			},
+		)
+	case kadmin.KAdminErrorMsg:
+		if m.state == VALIDATING {
+			m.notifier.ShowErrorMsg("Invalid Topic Config", msg.Error)
+		} else {
+			m.notifier.ShowErrorMsg("Update failed", msg.Error)
+		}
+		m.state = UPDATE_FAILED
+		return nil, nil
 	case kadmin.UpdateTopicConfigErrorMsg:
 		m.state = UPDATE_FAILED
 		m.notifier.ShowErrorMsg(msg.Reason, fmt.Errorf("TODO"))
@@ -152,6 +181,44 @@ func (m *CmdBarModel) Update(msg tea.Msg, stc SelectedTopicConfig) (tea.Msg, tea
 	return msg, nil
 }
 
+func (m *CmdBarModel) edit(stc SelectedTopicConfig, operation kadmin.ConfigOperation, value string, placeholder string) {
+	if stc.ReadOnly {
+		m.state = UPDATE_FAILED
+		m.notifier.ShowErrorMsg("Unable to edit", fmt.Errorf("%s is read-only", stc.ConfigKey))
+		return
+	}
+	m.state = EDITING
+	m.operation = operation
+	m.editInput = newEditInput(value, placeholder)
+	m.editInput.Focus()
+}
+
+// resetToDefault removes the topic override, the same dry run guards it as other updates.
+func (m *CmdBarModel) resetToDefault(stc SelectedTopicConfig) tea.Cmd {
+	if stc.ReadOnly {
+		m.state = UPDATE_FAILED
+		m.notifier.ShowErrorMsg("Unable to reset", fmt.Errorf("%s is read-only", stc.ConfigKey))
+		return nil
+	}
+	if !stc.Override {
+		m.state = UPDATE_FAILED
+		m.notifier.ShowErrorMsg("Unable to reset", fmt.Errorf("%s is not overridden on the topic", stc.ConfigKey))
+		return nil
+	}
+	m.state = VALIDATING
+	toUpdate := kadmin.TopicConfigToUpdate{
+		Topic:     stc.Topic,
+		Key:       stc.ConfigKey,
+		Operation: kadmin.DeleteConfig,
+	}
+	return tea.Batch(
+		m.notifier.SpinWithLoadingMsg("Validating Topic Config"),
+		func() tea.Msg {
+			return m.configUpdater.ValidateConfig(toUpdate)
+		},
+	)
+}
+
 func isEditable(m *CmdBarModel) bool {
 	return m.state == HIDDEN ||
 		m.state == SEARCHED ||
@@ -171,7 +238,7 @@ func (m *CmdBarModel) GetSearchTerm() string {
 }
 
 func (m *CmdBarModel) IsLoading() bool {
-	return m.state == LOADING || m.state == UPDATING
+	return m.state == LOADING || m.state == VALIDATING || m.state == UPDATING
 }
 
 func newSearchInput() *huh.Input {
@@ -181,10 +248,10 @@ func newSearchInput() *huh.Input {
 	return searchInput
 }
 
-func newEditInput(v string) *huh.Input {
+func newEditInput(v string, placeholder string) *huh.Input {
 	searchInput := huh.NewInput().
 		Value(&v).
-		Placeholder("New config value")
+		Placeholder(placeholder)
 	searchInput.Init()
 	return searchInput
 }
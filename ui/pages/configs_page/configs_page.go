@@ -14,11 +14,15 @@ import (
 	"strings"
 )
 
+// overrideMarker prefixes the configs set on the topic itself.
+const overrideMarker = "* "
+
 type Model struct {
 	rows    []table.Row
+	keys    []string
 	table   *table.Model
 	cmdBar  *CmdBarModel
-	configs map[string]string
+	configs map[string]kadmin.TopicConfig
 	topic   string
 	err     error
 }
@@ -33,10 +37,18 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	//	builder.WriteString(m.err.Error())
 	//}
 	m.table.SetColumns([]table.Column{
-		{Title: "Config", Width: int(float64(ktx.WindowWidth-5) * 0.5)},
-		{Title: "Value", Width: int(float64(ktx.WindowWidth-5) * 0.5)},
+		{Title: "Config", Width: int(float64(ktx.WindowWidth-9) * 0.3)},
+		{Title: "Value", Width: int(float64(ktx.WindowWidth-9) * 0.2)},
+		{Title: "Default", Width: int(float64(ktx.WindowWidth-9) * 0.2)},
+		{Title: "Source", Width: int(float64(ktx.WindowWidth-9) * 0.3)},
 	})
-	m.table.SetHeight(ktx.AvailableHeight - 2)
+	// the documentation of the selected config is shown below the table
+	doc := m.docView(ktx.WindowWidth - 2)
+	tableHeight := ktx.AvailableHeight - 2
+	if doc != "" {
+		tableHeight -= lipgloss.Height(doc)
+	}
+	m.table.SetHeight(tableHeight)
 	m.table.SetRows(m.rows)
 	m.table.Focus()
 	if m.cmdBar.IsFocused() {
@@ -44,10 +56,29 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	} else {
 		views = append(views, styles.Table.Focus.Render(m.table.View()))
 	}
+	if doc != "" {
+		views = append(views, renderer.Render(doc))
+	}
 
 	return ui.JoinVertical(lipgloss.Top, views...)
 }
 
+func (m *Model) docView(width int) string {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.keys) {
+		return ""
+	}
+	doc := m.configs[m.keys[cursor]].Doc
+	if doc == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Padding(0, 1).
+		Foreground(lipgloss.Color(styles.ColorGrey)).
+		Render(doc)
+}
+
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 	switch msg := msg.(type) {
@@ -55,16 +86,7 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		if m.cmdBar.IsLoading() {
 			return nil
 		}
-		selectedRow := m.table.SelectedRow()
-		var config SelectedTopicConfig
-		if selectedRow != nil {
-			config = SelectedTopicConfig{
-				Topic:       m.topic,
-				ConfigKey:   selectedRow[0],
-				ConfigValue: selectedRow[1],
-			}
-		}
-		um, c := m.cmdBar.Update(msg, config)
+		um, c := m.cmdBar.Update(msg, m.selectedConfig())
 		if c != nil {
 			cmds = append(cmds, c)
 		}
@@ -82,16 +104,7 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.cmdBar.Update(msg, SelectedTopicConfig{})
 		m.configs = msg.Configs
 	default:
-		selectedRow := m.table.SelectedRow()
-		var config SelectedTopicConfig
-		if selectedRow != nil {
-			config = SelectedTopicConfig{
-				Topic:       m.topic,
-				ConfigKey:   selectedRow[0],
-				ConfigValue: selectedRow[1],
-			}
-		}
-		_, c := m.cmdBar.Update(msg, config)
+		_, c := m.cmdBar.Update(msg, m.selectedConfig())
 		return c
 	}
 
@@ -108,16 +121,50 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	sort.Strings(keys)
 	var rows []table.Row
 	for _, k := range keys {
-		rows = append(rows, table.Row{k, m.configs[k]})
+		c := m.configs[k]
+		name := k
+		if c.IsOverride() {
+			name = overrideMarker + name
+		}
+		value := c.Value
+		defaultValue := c.Default
+		if c.Sensitive {
+			value = "<sensitive>"
+			defaultValue = "<sensitive>"
+		}
+		source := c.Source.String()
+		if c.ReadOnly {
+			source += " (read-only)"
+		}
+		rows = append(rows, table.Row{name, value, defaultValue, source})
 	}
+	m.keys = keys
 	m.rows = rows
 	return tea.Batch(cmds...)
 }
 
+func (m *Model) selectedConfig() SelectedTopicConfig {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.keys) {
+		return SelectedTopicConfig{}
+	}
+	c := m.configs[m.keys[cursor]]
+	return SelectedTopicConfig{
+		Topic:       m.topic,
+		ConfigKey:   c.Name,
+		ConfigValue: c.Value,
+		ReadOnly:    c.ReadOnly,
+		Override:    c.IsOverride(),
+	}
+}
+
 func (m *Model) Shortcuts() []statusbar.Shortcut {
 	return []statusbar.Shortcut{
 		{"Search", "/"},
 		{"Edit", "e"},
+		{"Reset to Default", "r"},
+		{"Append", "a"},
+		{"Subtract", "s"},
 		{"Go Back", "esc"},
 	}
 }
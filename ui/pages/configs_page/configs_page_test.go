@@ -1,11 +1,13 @@
 package configs_page
 
 import (
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/tests"
 	"ktea/tests/keys"
 	"ktea/ui"
 	"strings"
@@ -14,6 +16,7 @@ import (
 
 type MockKAdmin struct {
 	UpdateConfigFunc      func(t kadmin.TopicConfigToUpdate) tea.Msg
+	ValidateConfigFunc    func(t kadmin.TopicConfigToUpdate) tea.Msg
 	TopicConfigListerFunc func(topic string) tea.Msg
 }
 
@@ -24,6 +27,13 @@ func (m *MockKAdmin) UpdateConfig(t kadmin.TopicConfigToUpdate) tea.Msg {
 	return nil
 }
 
+func (m *MockKAdmin) ValidateConfig(t kadmin.TopicConfigToUpdate) tea.Msg {
+	if m.ValidateConfigFunc != nil {
+		return m.ValidateConfigFunc(t)
+	}
+	return nil
+}
+
 func (m *MockKAdmin) ListConfigs(topic string) tea.Msg {
 	if m.TopicConfigListerFunc != nil {
 		return m.TopicConfigListerFunc(topic)
@@ -55,7 +65,7 @@ func TestConfigsPage(t *testing.T) {
 		section.Update(kadmin.TopicConfigUpdatedMsg{})
 		section.Update(kadmin.TopicConfigListingStartedMsg{})
 		section.Update(kadmin.TopicConfigsListedMsg{
-			Configs: map[string]string{"k": "v"},
+			Configs: defaultConfigs(map[string]string{"k": "v"}),
 		})
 
 		// then
@@ -124,12 +134,12 @@ func TestConfigsPage_Table(t *testing.T) {
 		section, _ := New(&MockKAdmin{}, &MockKAdmin{}, "topic")
 
 		section.Update(kadmin.TopicConfigsListedMsg{
-			Configs: map[string]string{
+			Configs: defaultConfigs(map[string]string{
 				"delete.retention.ms": "86400000",
 				"cleanup.policy":      "delete",
 				"max.message":         "1048588",
 				"segment.index":       "10485760",
-			},
+			}),
 		})
 
 		render := section.View(&kontext.ProgramKtx{
@@ -245,7 +255,127 @@ func TestConfigsPage_Editing(t *testing.T) {
 			WindowWidth:  100,
 		}, ui.TestRenderer)
 		assert.NotContains(t, render, "┃ > delete \n")
-		assert.Contains(t, render, "Updating Topic Config")
+		assert.Contains(t, render, "Validating Topic Config")
+	})
+
+	t.Run("validate before updating", func(t *testing.T) {
+		var validated, updated kadmin.TopicConfigToUpdate
+		section, _ := New(&MockKAdmin{
+			ValidateConfigFunc: func(t kadmin.TopicConfigToUpdate) tea.Msg {
+				validated = t
+				return kadmin.TopicConfigValidatedMsg{Config: t}
+			},
+			UpdateConfigFunc: func(t kadmin.TopicConfigToUpdate) tea.Msg {
+				updated = t
+				return kadmin.TopicConfigUpdatedMsg{}
+			},
+		}, &MockKAdmin{}, "topic")
+		section.Update(kadmin.TopicConfigsListedMsg{
+			Configs: defaultConfigs(map[string]string{"cleanup.policy": "delete"}),
+		})
+		section.View(&kontext.ProgramKtx{
+			WindowHeight: 19,
+			WindowWidth:  100,
+		}, ui.TestRenderer)
+
+		section.Update(keys.Key('e'))
+		section.Update(keys.Key(tea.KeyCtrlU))
+		keys.UpdateKeys(section, "compact")
+		msgs := tests.ExecuteBatchCmd(section.Update(keys.Key(tea.KeyEnter)))
+
+		expected := kadmin.TopicConfigToUpdate{
+			Topic: "topic",
+			Key:   "cleanup.policy",
+			Value: "compact",
+		}
+		assert.Equal(t, expected, validated)
+		assert.Equal(t, kadmin.TopicConfigToUpdate{}, updated)
+		assert.Contains(t, msgs, kadmin.TopicConfigValidatedMsg{Config: expected})
+
+		msgs = tests.ExecuteBatchCmd(section.Update(kadmin.TopicConfigValidatedMsg{Config: expected}))
+
+		assert.Equal(t, expected, updated)
+		assert.Contains(t, msgs, kadmin.TopicConfigUpdatedMsg{})
+	})
+
+	t.Run("show validation errors without updating", func(t *testing.T) {
+		section := newSection()
+
+		section.Update(keys.Key('e'))
+		section.Update(keys.Key(tea.KeyEnter))
+		cmd := section.Update(kadmin.KAdminErrorMsg{
+			Error: fmt.Errorf("Invalid value -1 for configuration delete.retention.ms"),
+		})
+
+		assert.Nil(t, cmd)
+		render := ansi.Strip(section.View(&kontext.ProgramKtx{
+			WindowHeight: 19,
+			WindowWidth:  100,
+		}, ui.TestRenderer))
+		assert.Contains(t, render, "Invalid Topic Config")
+		assert.Contains(t, render, "Invalid value -1 for configuration delete.retention.ms")
+	})
+
+	t.Run("a appends to a list config", func(t *testing.T) {
+		var validated kadmin.TopicConfigToUpdate
+		section, _ := New(&MockKAdmin{
+			ValidateConfigFunc: func(t kadmin.TopicConfigToUpdate) tea.Msg {
+				validated = t
+				return nil
+			},
+		}, &MockKAdmin{}, "topic")
+		section.Update(kadmin.TopicConfigsListedMsg{
+			Configs: defaultConfigs(map[string]string{"cleanup.policy": "delete"}),
+		})
+		section.View(&kontext.ProgramKtx{
+			WindowHeight: 19,
+			WindowWidth:  100,
+		}, ui.TestRenderer)
+
+		section.Update(keys.Key('a'))
+		render := section.View(&kontext.ProgramKtx{
+			WindowHeight: 19,
+			WindowWidth:  100,
+		}, ui.TestRenderer)
+		assert.Contains(t, render, "Value to append")
+
+		keys.UpdateKeys(section, "compact")
+		tests.ExecuteBatchCmd(section.Update(keys.Key(tea.KeyEnter)))
+
+		assert.Equal(t, kadmin.TopicConfigToUpdate{
+			Topic:     "topic",
+			Key:       "cleanup.policy",
+			Value:     "compact",
+			Operation: kadmin.AppendConfig,
+		}, validated)
+	})
+
+	t.Run("s subtracts from a list config", func(t *testing.T) {
+		var validated kadmin.TopicConfigToUpdate
+		section, _ := New(&MockKAdmin{
+			ValidateConfigFunc: func(t kadmin.TopicConfigToUpdate) tea.Msg {
+				validated = t
+				return nil
+			},
+		}, &MockKAdmin{}, "topic")
+		section.Update(kadmin.TopicConfigsListedMsg{
+			Configs: defaultConfigs(map[string]string{"cleanup.policy": "delete,compact"}),
+		})
+		section.View(&kontext.ProgramKtx{
+			WindowHeight: 19,
+			WindowWidth:  100,
+		}, ui.TestRenderer)
+
+		section.Update(keys.Key('s'))
+		keys.UpdateKeys(section, "delete")
+		tests.ExecuteBatchCmd(section.Update(keys.Key(tea.KeyEnter)))
+
+		assert.Equal(t, kadmin.TopicConfigToUpdate{
+			Topic:     "topic",
+			Key:       "cleanup.policy",
+			Value:     "delete",
+			Operation: kadmin.SubtractConfig,
+		}, validated)
 	})
 }
 
@@ -253,12 +383,139 @@ func newSection() *Model {
 	section, _ := New(&MockKAdmin{}, &MockKAdmin{}, "topic")
 
 	section.Update(kadmin.TopicConfigsListedMsg{
-		Configs: map[string]string{
+		Configs: defaultConfigs(map[string]string{
 			"delete.retention.ms": "86400000",
 			"cleanup.policy":      "delete",
 			"max.message":         "1048588",
 			"segment.index":       "10485760",
-		},
+		}),
 	})
 	return section
 }
+
+func defaultConfigs(values map[string]string) map[string]kadmin.TopicConfig {
+	configs := make(map[string]kadmin.TopicConfig)
+	for k, v := range values {
+		configs[k] = kadmin.TopicConfig{
+			Name:    k,
+			Value:   v,
+			Default: v,
+			Source:  kadmin.DefaultConfigSource,
+		}
+	}
+	return configs
+}
+
+func TestConfigsPage_Sources(t *testing.T) {
+	ktx := &kontext.ProgramKtx{
+		WindowHeight:    19,
+		WindowWidth:     100,
+		AvailableHeight: 100,
+	}
+
+	newSourcesSection := func(validate func(t kadmin.TopicConfigToUpdate) tea.Msg) *Model {
+		section, _ := New(&MockKAdmin{ValidateConfigFunc: validate}, &MockKAdmin{}, "topic")
+		section.Update(kadmin.TopicConfigsListedMsg{
+			Configs: map[string]kadmin.TopicConfig{
+				"cleanup.policy": {
+					Name:    "cleanup.policy",
+					Value:   "delete",
+					Default: "delete",
+					Source:  kadmin.DefaultConfigSource,
+				},
+				"message.format.version": {
+					Name:     "message.format.version",
+					Value:    "3.0-IV1",
+					Default:  "3.0-IV1",
+					Source:   kadmin.StaticBrokerConfigSource,
+					ReadOnly: true,
+				},
+				"retention.ms": {
+					Name:    "retention.ms",
+					Value:   "3600000",
+					Default: "604800000",
+					Source:  kadmin.TopicConfigSource,
+					Doc:     "This configuration controls the maximum time we will retain a log.",
+				},
+				"sasl.jaas.config": {
+					Name:      "sasl.jaas.config",
+					Source:    kadmin.TopicConfigSource,
+					Sensitive: true,
+				},
+			},
+		})
+		section.View(ktx, ui.TestRenderer)
+		return section
+	}
+
+	t.Run("Show source and default of configs", func(t *testing.T) {
+		section := newSourcesSection(nil)
+
+		render := section.View(ktx, ui.TestRenderer)
+
+		assert.Contains(t, render, "* retention.ms")
+		assert.Contains(t, render, "604800000")
+		assert.Contains(t, render, "static broker (read-only)")
+		assert.NotContains(t, render, "* cleanup.policy")
+		assert.Contains(t, render, "<sensitive>")
+	})
+
+	t.Run("Show documentation of the selected config", func(t *testing.T) {
+		section := newSourcesSection(nil)
+
+		render := section.View(ktx, ui.TestRenderer)
+		assert.NotContains(t, render, "maximum time we will retain a log")
+
+		section.Update(keys.Key(tea.KeyDown))
+		section.Update(keys.Key(tea.KeyDown))
+
+		render = section.View(ktx, ui.TestRenderer)
+		assert.Contains(t, render, "This configuration controls the maximum time we will retain a log.")
+	})
+
+	t.Run("Block editing read-only configs", func(t *testing.T) {
+		section := newSourcesSection(nil)
+		section.Update(keys.Key(tea.KeyDown))
+
+		section.Update(keys.Key('e'))
+
+		render := ansi.Strip(section.View(ktx, ui.TestRenderer))
+		assert.Contains(t, render, "message.format.version is read-only")
+		assert.NotContains(t, render, "New config value")
+	})
+
+	t.Run("Reset override to default", func(t *testing.T) {
+		var validated kadmin.TopicConfigToUpdate
+		section := newSourcesSection(func(t kadmin.TopicConfigToUpdate) tea.Msg {
+			validated = t
+			return nil
+		})
+		section.Update(keys.Key(tea.KeyDown))
+		section.Update(keys.Key(tea.KeyDown))
+
+		tests.ExecuteBatchCmd(section.Update(keys.Key('r')))
+
+		assert.Equal(t, kadmin.TopicConfigToUpdate{
+			Topic:     "topic",
+			Key:       "retention.ms",
+			Operation: kadmin.DeleteConfig,
+		}, validated)
+		render := ansi.Strip(section.View(ktx, ui.TestRenderer))
+		assert.Contains(t, render, "Validating Topic Config")
+	})
+
+	t.Run("Reset of a config that is not overridden", func(t *testing.T) {
+		validated := false
+		section := newSourcesSection(func(t kadmin.TopicConfigToUpdate) tea.Msg {
+			validated = true
+			return nil
+		})
+
+		cmd := section.Update(keys.Key('r'))
+
+		assert.Nil(t, cmd)
+		assert.False(t, validated)
+		render := ansi.Strip(section.View(ktx, ui.TestRenderer))
+		assert.Contains(t, render, "cleanup.policy is not overridden on the topic")
+	})
+}
@@ -1,6 +1,8 @@
 package consumption_form_page
 
 import (
+	"errors"
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
@@ -10,9 +12,19 @@ import (
 	"ktea/ui"
 	"ktea/ui/components/statusbar"
 	"ktea/ui/pages/nav"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// timestampLayout is the layout in which start and end timestamps are entered, in local time.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// followLimit is the limit option that keeps on following new records instead of stopping at a limit.
+const followLimit = -1
+
 type selectionState int
 
 const (
@@ -25,20 +37,33 @@ type Model struct {
 	topic                     *kadmin.Topic
 	formValues                *formValues
 	windowResized             bool
+	timestampSelectionState   selectionState
+	offsetSelectionState      selectionState
 	keyFilterSelectionState   selectionState
 	valueFilterSelectionState selectionState
 	ktx                       *kontext.ProgramKtx
 	availableHeight           int
+	topicGroupFieldCount      int
+	// headerFilterFieldCount is the number of header filter fields the form has been built with
+	headerFilterFieldCount int
+	// copying is true when the records are selected to be copied instead of consumed
+	copying bool
 }
 
 type formValues struct {
 	startPoint      kadmin.StartPoint
+	startTimestamp  string
+	endTimestamp    string
+	offsets         string
 	limit           int
 	partitions      []int
 	keyFilter       kadmin.FilterType
 	keyFilterTerm   string
 	valueFilter     kadmin.FilterType
 	valueFilterTerm string
+	headerFilter    kadmin.FilterType
+	headerKey       string
+	headerValue     string
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -66,17 +91,37 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.form = f
 	}
 
+	if m.formValues.startPoint == kadmin.Timestamp && m.timestampSelectionState == notSelected {
+		// if timestamp start point is selected and previously not selected
+		m.timestampSelectionState = selected
+		m.form = m.newForm(m.topic.Partitions, m.ktx)
+	} else if m.formValues.startPoint != kadmin.Timestamp && m.timestampSelectionState == selected {
+		// if timestamp start point is deselected and previously selected
+		m.timestampSelectionState = notSelected
+		m.form = m.newForm(m.topic.Partitions, m.ktx)
+	}
+
+	if m.formValues.startPoint == kadmin.SpecificOffset && m.offsetSelectionState == notSelected {
+		// if specific offset start point is selected and previously not selected
+		m.offsetSelectionState = selected
+		m.form = m.newForm(m.topic.Partitions, m.ktx)
+	} else if m.formValues.startPoint != kadmin.SpecificOffset && m.offsetSelectionState == selected {
+		// if specific offset start point is deselected and previously selected
+		m.offsetSelectionState = notSelected
+		m.form = m.newForm(m.topic.Partitions, m.ktx)
+	}
+
 	if m.formValues.keyFilter != kadmin.NoFilterType && m.keyFilterSelectionState == notSelected {
 		// if key filter type is selected and previously not selected
 		m.keyFilterSelectionState = selected
 		m.form = m.newForm(m.topic.Partitions, m.ktx)
-		m.NextField(3)
+		m.NextField(m.topicGroupFieldCount)
 		m.form.NextGroup()
 	} else if m.formValues.keyFilter == kadmin.NoFilterType && m.keyFilterSelectionState == selected {
 		// if no key filter type is selected and previously selected
 		m.keyFilterSelectionState = notSelected
 		m.form = m.newForm(m.topic.Partitions, m.ktx)
-		m.NextField(3)
+		m.NextField(m.topicGroupFieldCount)
 		m.form.NextGroup()
 	}
 
@@ -84,18 +129,26 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		// if value filter type is selected and previously not selected
 		m.valueFilterSelectionState = selected
 		m.form = m.newForm(m.topic.Partitions, m.ktx)
-		m.NextField(3)
+		m.NextField(m.topicGroupFieldCount)
 		m.form.NextGroup()
 		m.NextField(1)
 	} else if m.formValues.valueFilter == kadmin.NoFilterType && m.valueFilterSelectionState == selected {
 		// if no key filter type is selected and previously selected
 		m.valueFilterSelectionState = notSelected
 		m.form = m.newForm(m.topic.Partitions, m.ktx)
-		m.NextField(3)
+		m.NextField(m.topicGroupFieldCount)
 		m.form.NextGroup()
 		m.NextField(1)
 	}
 
+	if m.headerFilterFields() != m.headerFilterFieldCount {
+		// header filter fields are shown or hidden depending on the header filter type
+		m.form = m.newForm(m.topic.Partitions, m.ktx)
+		m.NextField(m.topicGroupFieldCount)
+		m.form.NextGroup()
+		m.NextField(m.headerFilterTypeFieldIndex())
+	}
+
 	switch msg.(type) {
 	case tea.WindowSizeMsg:
 		m.windowResized = true
@@ -118,20 +171,111 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		filter.ValueSearchTerm = m.formValues.valueFilterTerm
 		filter.ValueFilter = m.formValues.valueFilter
 	}
+	if m.headerFilterFields() > 0 {
+		filter.HeaderFilter = m.formValues.headerFilter
+		filter.HeaderKey = m.formValues.headerKey
+		if m.formValues.headerFilter != kadmin.PresentFilterType {
+			filter.HeaderSearchTerm = m.formValues.headerValue
+		}
+	}
 	if m.form.State == huh.StateCompleted {
+		readDetails := kadmin.ReadDetails{
+			Topic:      m.topic,
+			Partitions: m.formValues.partitions,
+			StartPoint: m.formValues.startPoint,
+			Limit:      m.formValues.limit,
+			Filter:     &filter,
+		}
+		if m.formValues.limit == followLimit {
+			readDetails.Limit = 0
+			readDetails.Follow = true
+		}
+		if m.formValues.startPoint == kadmin.Timestamp {
+			// both timestamps have been validated by the form
+			readDetails.StartTimestamp, _ = parseTimestamp(m.formValues.startTimestamp)
+			if m.formValues.endTimestamp != "" {
+				endTimestamp, _ := parseTimestamp(m.formValues.endTimestamp)
+				readDetails.EndTimestamp = &endTimestamp
+			}
+		}
+		if m.formValues.startPoint == kadmin.SpecificOffset {
+			// offsets have been validated by the form
+			readDetails.PartitionOffsets, _ = parsePartitionOffsets(m.formValues.offsets, m.topic.Partitions)
+			readDetails.Partitions = slices.Sorted(maps.Keys(readDetails.PartitionOffsets))
+		}
+		if m.copying {
+			return ui.PublishMsg(nav.LoadCopyPageMsg{
+				ReadDetails: readDetails,
+			})
+		}
 		return ui.PublishMsg(nav.LoadConsumptionPageMsg{
-			ReadDetails: kadmin.ReadDetails{
-				Topic:      m.topic,
-				Partitions: m.formValues.partitions,
-				StartPoint: m.formValues.startPoint,
-				Limit:      m.formValues.limit,
-				Filter:     &filter,
-			},
+			ReadDetails: readDetails,
 		})
 	}
 	return cmd
 }
 
+func parseTimestamp(value string) (time.Time, error) {
+	return time.ParseInLocation(timestampLayout, value, time.Local)
+}
+
+// parsePartitionOffsets parses one partition per line in the format partition=start or partition=start-end.
+func parsePartitionOffsets(value string, partitions int) (map[int]kadmin.PartitionOffset, error) {
+	partitionOffsets := map[int]kadmin.PartitionOffset{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		partitionPart, offsetPart, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("'%s' is not in the format partition=start or partition=start-end", line)
+		}
+
+		partition, err := strconv.Atoi(strings.TrimSpace(partitionPart))
+		if err != nil || partition < 0 || partition >= partitions {
+			return nil, fmt.Errorf("'%s' is not a valid partition, expected 0 to %d", partitionPart, partitions-1)
+		}
+		if _, exists := partitionOffsets[partition]; exists {
+			return nil, fmt.Errorf("partition %d is entered more than once", partition)
+		}
+
+		startPart, endPart, hasEnd := strings.Cut(offsetPart, "-")
+		start, err := strconv.ParseInt(strings.TrimSpace(startPart), 10, 64)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("'%s' is not a valid start offset", startPart)
+		}
+		partitionOffset := kadmin.PartitionOffset{Start: start}
+		if hasEnd {
+			end, err := strconv.ParseInt(strings.TrimSpace(endPart), 10, 64)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("'%s' is not a valid end offset, it must not be before the start offset", endPart)
+			}
+			partitionOffset.End = &end
+		}
+		partitionOffsets[partition] = partitionOffset
+	}
+
+	if len(partitionOffsets) == 0 {
+		return nil, errors.New("enter at least one partition offset")
+	}
+	return partitionOffsets, nil
+}
+
+func formatPartitionOffsets(partitionOffsets map[int]kadmin.PartitionOffset) string {
+	var lines []string
+	for _, partition := range slices.Sorted(maps.Keys(partitionOffsets)) {
+		partitionOffset := partitionOffsets[partition]
+		line := fmt.Sprintf("%d=%d", partition, partitionOffset.Start)
+		if partitionOffset.End != nil {
+			line += fmt.Sprintf("-%d", *partitionOffset.End)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m *Model) Shortcuts() []statusbar.Shortcut {
 	return []statusbar.Shortcut{
 		{"Confirm", "enter"},
@@ -143,6 +287,9 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 }
 
 func (m *Model) Title() string {
+	if m.copying {
+		return "Copy details"
+	}
 	return "Consumption details"
 }
 
@@ -157,27 +304,46 @@ func (m *Model) newForm(partitions int, ktx *kontext.ProgramKtx) *huh.Form {
 	} else {
 		optionsHeight = m.availableHeight - optionsHeight
 	}
-	topicGroup := huh.NewGroup(
-		huh.NewSelect[kadmin.StartPoint]().
-			Value(&m.formValues.startPoint).
-			Title("Start form").
-			Options(
-				huh.NewOption("Beginning", kadmin.Beginning),
-				huh.NewOption("Most Recent", kadmin.MostRecent)),
-		huh.NewMultiSelect[int]().
+	var topicFields []huh.Field
+	topicFields = append(topicFields, huh.NewSelect[kadmin.StartPoint]().
+		Value(&m.formValues.startPoint).
+		Title("Start form").
+		Options(
+			huh.NewOption("Beginning", kadmin.Beginning),
+			huh.NewOption("Most Recent", kadmin.MostRecent),
+			huh.NewOption("Timestamp", kadmin.Timestamp),
+			huh.NewOption("Offset", kadmin.SpecificOffset)))
+	if m.formValues.startPoint == kadmin.Timestamp {
+		topicFields = append(topicFields, m.startTimestampField(), m.endTimestampField())
+	}
+	if m.formValues.startPoint == kadmin.SpecificOffset {
+		// the partitions to read are those offsets are entered for
+		topicFields = append(topicFields, m.offsetsField())
+	} else {
+		topicFields = append(topicFields, huh.NewMultiSelect[int]().
 			Value(&m.formValues.partitions).
 			Height(optionsHeight).
 			Title("Partitions").
 			Description(m.getPartitionDescription(ktx)).
-			Options(partOptions...),
+			Options(partOptions...))
+	}
+	limitOptions := []huh.Option[int]{
+		huh.NewOption("50", 50),
+		huh.NewOption("500", 500),
+		huh.NewOption("5000", 5000),
+	}
+	// a copy has to end, so it cannot follow
+	if !m.copying {
+		limitOptions = append(limitOptions, huh.NewOption("Follow", followLimit))
+	}
+	topicFields = append(topicFields,
 		huh.NewSelect[int]().
 			Value(&m.formValues.limit).
 			Title("Limit").
-			Options(
-				huh.NewOption("50", 50),
-				huh.NewOption("500", 500),
-				huh.NewOption("5000", 5000)),
+			Options(limitOptions...),
 	)
+	m.topicGroupFieldCount = len(topicFields)
+	topicGroup := huh.NewGroup(topicFields...)
 	filterGroup := m.createFilterGroup()
 	form := huh.NewForm(
 		topicGroup.WithWidth(ktx.WindowWidth/2),
@@ -188,6 +354,52 @@ func (m *Model) newForm(partitions int, ktx *kontext.ProgramKtx) *huh.Form {
 	return form
 }
 
+func (m *Model) startTimestampField() *huh.Input {
+	return huh.NewInput().
+		Value(&m.formValues.startTimestamp).
+		Title("Start Timestamp").
+		Description("Local time, e.g. " + time.Now().Format(timestampLayout)).
+		Validate(func(str string) error {
+			if _, err := parseTimestamp(str); err != nil {
+				return fmt.Errorf("'%s' is not a valid timestamp, expected format is %s", str, timestampLayout)
+			}
+			return nil
+		})
+}
+
+func (m *Model) endTimestampField() *huh.Input {
+	return huh.NewInput().
+		Value(&m.formValues.endTimestamp).
+		Title("End Timestamp").
+		Description("Leave empty to read up until the most recent record.").
+		Validate(func(str string) error {
+			if str == "" {
+				return nil
+			}
+			end, err := parseTimestamp(str)
+			if err != nil {
+				return fmt.Errorf("'%s' is not a valid timestamp, expected format is %s", str, timestampLayout)
+			}
+			if start, err := parseTimestamp(m.formValues.startTimestamp); err == nil && !end.After(start) {
+				return errors.New("end timestamp must be after the start timestamp")
+			}
+			return nil
+		})
+}
+
+func (m *Model) offsetsField() *huh.Text {
+	return huh.NewText().
+		Value(&m.formValues.offsets).
+		Title("Offsets").
+		Description("Enter partition=start or partition=start-end, one partition per line.").
+		ShowLineNumbers(true).
+		Lines(5).
+		Validate(func(str string) error {
+			_, err := parsePartitionOffsets(str, m.topic.Partitions)
+			return err
+		})
+}
+
 func (m *Model) createFilterGroup() *huh.Group {
 	var fields []huh.Field
 
@@ -201,39 +413,127 @@ func (m *Model) createFilterGroup() *huh.Group {
 		fields = append(fields, m.valueFilterTermField())
 	}
 
+	fields = append(fields, m.headerFilterTypeField())
+	m.headerFilterFieldCount = m.headerFilterFields()
+	if m.headerFilterFieldCount > 0 {
+		fields = append(fields, m.headerKeyField())
+	}
+	if m.headerFilterFieldCount > 1 {
+		fields = append(fields, m.headerValueField())
+	}
+
 	return huh.NewGroup(fields...)
 }
 
+// headerFilterFields returns the number of fields the selected header filter type requires
+func (m *Model) headerFilterFields() int {
+	switch m.formValues.headerFilter {
+	case "", kadmin.NoFilterType:
+		return 0
+	case kadmin.PresentFilterType:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// headerFilterTypeFieldIndex returns the position of the header filter type field within the filter group
+func (m *Model) headerFilterTypeFieldIndex() int {
+	index := 2
+	if m.formValues.keyFilter != kadmin.NoFilterType {
+		index++
+	}
+	if m.formValues.valueFilter != kadmin.NoFilterType {
+		index++
+	}
+	return index
+}
+
+func (m *Model) headerFilterTypeField() *huh.Select[kadmin.FilterType] {
+	return huh.NewSelect[kadmin.FilterType]().
+		Value(&m.formValues.headerFilter).
+		Title("Header Filter Type").
+		Options(
+			huh.NewOption("None", kadmin.NoFilterType),
+			huh.NewOption("Present", kadmin.PresentFilterType),
+			huh.NewOption("Equals", kadmin.EqualsFilterType),
+			huh.NewOption("Contains", kadmin.ContainsFilterType),
+			huh.NewOption("Starts With", kadmin.StartsWithFilterType))
+}
+
+func (m *Model) headerKeyField() *huh.Input {
+	return huh.NewInput().
+		Value(&m.formValues.headerKey).
+		Title("Header Key").
+		Validate(func(str string) error {
+			if str == "" {
+				return errors.New("header key is required")
+			}
+			return nil
+		})
+}
+
+func (m *Model) headerValueField() *huh.Input {
+	return huh.NewInput().
+		Value(&m.formValues.headerValue).
+		Title("Header Value")
+}
+
 func (m *Model) valueFilterTermField() *huh.Input {
 	return huh.NewInput().
 		Value(&m.formValues.valueFilterTerm).
-		Title("Value Filter Term")
+		Title("Value Filter Term").
+		DescriptionFunc(func() string {
+			return filterTermDescription(m.formValues.valueFilter)
+		}, &m.formValues.valueFilter).
+		Validate(func(str string) error {
+			return kadmin.ValidateFilterTerm(m.formValues.valueFilter, str)
+		})
 }
 
 func (m *Model) valueFilterTypeField() *huh.Select[kadmin.FilterType] {
 	return huh.NewSelect[kadmin.FilterType]().
 		Value(&m.formValues.valueFilter).
 		Title("Value Filter Type").
-		Options(
-			huh.NewOption("None", kadmin.NoFilterType),
-			huh.NewOption("Contains", kadmin.ContainsFilterType),
-			huh.NewOption("Starts With", kadmin.StartsWithFilterType))
+		Options(filterTypeOptions()...)
+}
+
+func filterTypeOptions() []huh.Option[kadmin.FilterType] {
+	return []huh.Option[kadmin.FilterType]{
+		huh.NewOption("None", kadmin.NoFilterType),
+		huh.NewOption("Contains", kadmin.ContainsFilterType),
+		huh.NewOption("Starts With", kadmin.StartsWithFilterType),
+		huh.NewOption("Exact", kadmin.EqualsFilterType),
+		huh.NewOption("Not Contains", kadmin.NotContainsFilterType),
+		huh.NewOption("Regex", kadmin.RegexFilterType),
+		huh.NewOption("JSON Path", kadmin.JsonPathFilterType),
+	}
+}
+
+func filterTermDescription(filterType kadmin.FilterType) string {
+	if filterType == kadmin.JsonPathFilterType {
+		return `e.g. $.order.status == "FAILED"`
+	}
+	return ""
 }
 
 func (m *Model) keyFilterTermField() *huh.Input {
 	return huh.NewInput().
 		Value(&m.formValues.keyFilterTerm).
-		Title("Key Filter Term")
+		Title("Key Filter Term").
+		DescriptionFunc(func() string {
+			return filterTermDescription(m.formValues.keyFilter)
+		}, &m.formValues.keyFilter).
+		Validate(func(str string) error {
+			return kadmin.ValidateFilterTerm(m.formValues.keyFilter, str)
+		})
 }
 
 func (m *Model) keyFilterTypeField() *huh.Select[kadmin.FilterType] {
 	return huh.NewSelect[kadmin.FilterType]().
 		Value(&m.formValues.keyFilter).
 		Title("Key Filter Type").
-		Options(
-			huh.NewOption("None", kadmin.NoFilterType),
-			huh.NewOption("Contains", kadmin.ContainsFilterType),
-			huh.NewOption("Starts With", kadmin.StartsWithFilterType))
+		Options(filterTypeOptions()...)
 }
 
 // hack until https://github.com/charmbracelet/huh/issues/525 has been resolved
@@ -254,20 +554,47 @@ func (m *Model) NextField(count int) {
 }
 
 func NewWithDetails(details *kadmin.ReadDetails, ktx *kontext.ProgramKtx) *Model {
+	values := &formValues{
+		startPoint:      details.StartPoint,
+		limit:           details.Limit,
+		partitions:      details.Partitions,
+		keyFilter:       details.Filter.KeyFilter,
+		keyFilterTerm:   details.Filter.KeySearchTerm,
+		valueFilter:     details.Filter.ValueFilter,
+		valueFilterTerm: details.Filter.ValueSearchTerm,
+		headerFilter:    details.Filter.HeaderFilter,
+		headerKey:       details.Filter.HeaderKey,
+		headerValue:     details.Filter.HeaderSearchTerm,
+	}
+	if details.Follow {
+		values.limit = followLimit
+	}
+	var timestampSelectionState, offsetSelectionState selectionState
+	if details.StartPoint == kadmin.SpecificOffset {
+		offsetSelectionState = selected
+		values.offsets = formatPartitionOffsets(details.PartitionOffsets)
+	}
+	if details.StartPoint == kadmin.Timestamp {
+		timestampSelectionState = selected
+		values.startTimestamp = details.StartTimestamp.Format(timestampLayout)
+		if details.EndTimestamp != nil {
+			values.endTimestamp = details.EndTimestamp.Format(timestampLayout)
+		}
+	}
 	return &Model{
-		topic: details.Topic,
-		ktx:   ktx,
-		formValues: &formValues{
-			startPoint:      details.StartPoint,
-			limit:           details.Limit,
-			partitions:      details.Partitions,
-			keyFilter:       details.Filter.KeyFilter,
-			keyFilterTerm:   details.Filter.KeySearchTerm,
-			valueFilter:     details.Filter.ValueFilter,
-			valueFilterTerm: details.Filter.ValueSearchTerm,
-		}}
+		topic:                   details.Topic,
+		ktx:                     ktx,
+		timestampSelectionState: timestampSelectionState,
+		offsetSelectionState:    offsetSelectionState,
+		formValues:              values,
+	}
 }
 
 func New(topic *kadmin.Topic, ktx *kontext.ProgramKtx) *Model {
 	return &Model{topic: topic, formValues: &formValues{}, ktx: ktx}
 }
+
+// NewForCopy selects the records of the topic to copy.
+func NewForCopy(topic *kadmin.Topic, ktx *kontext.ProgramKtx) *Model {
+	return &Model{topic: topic, formValues: &formValues{}, ktx: ktx, copying: true}
+}
@@ -11,6 +11,7 @@ import (
 	"ktea/ui"
 	"ktea/ui/pages/nav"
 	"testing"
+	"time"
 )
 
 func TestConsumeForm_Navigation(t *testing.T) {
@@ -147,6 +148,10 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		// next field
 		cmd = m.Update(cmd())
 		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
 		msgs := keys.Submit(m)
 
 		assert.Equal(t, nav.LoadConsumptionPageMsg{
@@ -168,6 +173,438 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		}, msgs[0])
 	})
 
+	t.Run("selecting timestamp start point displays start and end timestamp fields", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "Start Timestamp")
+
+		// select start from timestamp
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+
+		render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Start Timestamp")
+		assert.Contains(t, render, "End Timestamp")
+
+		t.Run("selecting another start point hides timestamp fields again", func(t *testing.T) {
+			m.Update(keys.Key(tea.KeyUp))
+
+			render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.NotContains(t, render, "Start Timestamp")
+			assert.NotContains(t, render, "End Timestamp")
+		})
+	})
+
+	t.Run("submitting form with timestamp start point", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// select start from timestamp
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// start timestamp
+		keys.UpdateKeys(m, "2025-03-01 10:00:00")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// end timestamp
+		keys.UpdateKeys(m, "2025-03-01 11:30:00")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select no partitions
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// select limit 50
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
+		msgs := keys.Submit(m)
+
+		endTimestamp := time.Date(2025, 3, 1, 11, 30, 0, 0, time.Local)
+		assert.Equal(t, nav.LoadConsumptionPageMsg{
+			ReadDetails: kadmin.ReadDetails{
+				Topic: &kadmin.Topic{
+					Name:       "topic1",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Filter: &kadmin.Filter{
+					KeySearchTerm:   "",
+					ValueSearchTerm: "",
+				},
+				Limit:          50,
+				Partitions:     []int{},
+				StartPoint:     kadmin.Timestamp,
+				StartTimestamp: time.Date(2025, 3, 1, 10, 0, 0, 0, time.Local),
+				EndTimestamp:   &endTimestamp,
+			},
+		}, msgs[0])
+	})
+
+	t.Run("end timestamp before start timestamp is invalid", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// select start from timestamp
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// start timestamp
+		keys.UpdateKeys(m, "2025-03-01 10:00:00")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// end timestamp
+		keys.UpdateKeys(m, "2025-03-01 09:00:00")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "end timestamp must be after the start timestamp")
+	})
+
+	t.Run("submitting form with specific offsets start point", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// select start from offset
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "Partitions")
+
+		// offsets
+		keys.UpdateKeys(m, "7=1234567-1234600")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select limit 50
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
+		msgs := keys.Submit(m)
+
+		endOffset := int64(1234600)
+		assert.Equal(t, nav.LoadConsumptionPageMsg{
+			ReadDetails: kadmin.ReadDetails{
+				Topic: &kadmin.Topic{
+					Name:       "topic1",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Filter: &kadmin.Filter{
+					KeySearchTerm:   "",
+					ValueSearchTerm: "",
+				},
+				Limit:      50,
+				Partitions: []int{7},
+				StartPoint: kadmin.SpecificOffset,
+				PartitionOffsets: map[int]kadmin.PartitionOffset{
+					7: {Start: 1234567, End: &endOffset},
+				},
+			},
+		}, msgs[0])
+	})
+
+	t.Run("offsets of an unknown partition are invalid", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// select start from offset
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// offsets
+		keys.UpdateKeys(m, "10=5")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "'10' is not a valid partition, expected 0 to 9")
+	})
+
+	t.Run("submitting form with follow limit follows the topic", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// select start from most recent
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select no partitions
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// select follow
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
+		msgs := keys.Submit(m)
+
+		assert.Equal(t, nav.LoadConsumptionPageMsg{
+			ReadDetails: kadmin.ReadDetails{
+				Topic: &kadmin.Topic{
+					Name:       "topic1",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Filter: &kadmin.Filter{
+					KeySearchTerm:   "",
+					ValueSearchTerm: "",
+				},
+				Limit:      0,
+				Follow:     true,
+				Partitions: []int{},
+				StartPoint: kadmin.MostRecent,
+			},
+		}, msgs[0])
+	})
+
+	t.Run("submitting the copy form loads the copy page without following", func(t *testing.T) {
+		m := NewForCopy(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.NotContains(t, render, "Follow")
+		assert.Equal(t, "Copy details", m.Title())
+
+		// select start from most recent
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select no partitions
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// select limit 5000
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
+		msgs := keys.Submit(m)
+
+		assert.Equal(t, nav.LoadCopyPageMsg{
+			ReadDetails: kadmin.ReadDetails{
+				Topic: &kadmin.Topic{
+					Name:       "topic1",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Filter: &kadmin.Filter{
+					KeySearchTerm:   "",
+					ValueSearchTerm: "",
+				},
+				Limit:      5000,
+				Partitions: []int{},
+				StartPoint: kadmin.MostRecent,
+			},
+		}, msgs[0])
+	})
+
+	t.Run("filter on header value", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// start from beginning
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select no partitions
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// select limit 50
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// equals header filter
+		m.Update(keys.Key(tea.KeyDown))
+		m.Update(keys.Key(tea.KeyDown))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Header Key")
+		assert.Contains(t, render, "Header Value")
+
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		keys.UpdateKeys(m, "eventType")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		keys.UpdateKeys(m, "OrderFailed")
+		msgs := keys.Submit(m)
+
+		assert.Equal(t, nav.LoadConsumptionPageMsg{
+			ReadDetails: kadmin.ReadDetails{
+				Topic: &kadmin.Topic{
+					Name:       "topic1",
+					Partitions: 10,
+					Replicas:   1,
+					Isr:        1,
+				},
+				Filter: &kadmin.Filter{
+					HeaderFilter:     kadmin.EqualsFilterType,
+					HeaderKey:        "eventType",
+					HeaderSearchTerm: "OrderFailed",
+				},
+				Limit:      50,
+				Partitions: []int{},
+				StartPoint: kadmin.Beginning,
+			},
+		}, msgs[0])
+	})
+
+	t.Run("header present filter only asks for the header key", func(t *testing.T) {
+		m := NewWithDetails(&kadmin.ReadDetails{
+			Topic: &kadmin.Topic{
+				Name:       "topic1",
+				Partitions: 10,
+				Replicas:   1,
+				Isr:        1,
+			},
+			Limit: 50,
+			Filter: &kadmin.Filter{
+				KeyFilter:    kadmin.NoFilterType,
+				ValueFilter:  kadmin.NoFilterType,
+				HeaderFilter: kadmin.PresentFilterType,
+				HeaderKey:    "traceId",
+			},
+		}, ui.NewTestKontext())
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "Header Key")
+		assert.Contains(t, render, "traceId")
+		assert.NotContains(t, render, "Header Value")
+	})
+
 	t.Run("selecting partitions is optional", func(t *testing.T) {
 		m := New(&kadmin.Topic{
 			Name:       "topic1",
@@ -199,6 +636,10 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		// next field
 		cmd = m.Update(cmd())
 		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
 		msgs := keys.Submit(m)
 
 		assert.Equal(t, nav.LoadConsumptionPageMsg{
@@ -288,6 +729,10 @@ func TestConsumeForm_Navigation(t *testing.T) {
 			// next field
 			cmd = m.Update(cmd())
 			// no value filter
+			cmd = m.Update(keys.Key(tea.KeyEnter))
+			// next field
+			cmd = m.Update(cmd())
+			// no header filter
 			msgs := keys.Submit(m)
 
 			assert.Equal(t, nav.LoadConsumptionPageMsg{
@@ -348,6 +793,10 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		// next field
 		cmd = m.Update(cmd())
 		// no value filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// no header filter
 		msgs := keys.Submit(m)
 
 		assert.EqualValues(t, nav.LoadConsumptionPageMsg{
@@ -370,6 +819,48 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		}, msgs[0])
 	})
 
+	t.Run("regex value filter term must be a valid regular expression", func(t *testing.T) {
+		m := New(&kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, ui.NewTestKontext())
+		// make sure form has been initialized
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		// start from beginning
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// select no partitions
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// select limit 50
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		cmd = m.Update(cmd())
+		// next group
+		m.Update(cmd())
+		// no key filter
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		// regex value filter
+		for i := 0; i < 5; i++ {
+			m.Update(keys.Key(tea.KeyDown))
+		}
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		// next field
+		m.Update(cmd())
+		keys.UpdateKeys(m, "order-(")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "missing closing )")
+	})
+
 	t.Run("selecting value filter type starts-with displays filter value field", func(t *testing.T) {
 		m := New(&kadmin.Topic{
 			Name:       "topic1",
@@ -457,6 +948,10 @@ func TestConsumeForm_Navigation(t *testing.T) {
 			// next field
 			cmd = m.Update(cmd())
 			// no value filter
+			cmd = m.Update(keys.Key(tea.KeyEnter))
+			// next field
+			cmd = m.Update(cmd())
+			// no header filter
 			msgs := keys.Submit(m)
 
 			assert.Equal(t, nav.LoadConsumptionPageMsg{
@@ -479,3 +974,32 @@ func TestConsumeForm_Navigation(t *testing.T) {
 		})
 	})
 }
+
+func TestParsePartitionOffsets(t *testing.T) {
+	t.Run("multiple partitions", func(t *testing.T) {
+		offsets, err := parsePartitionOffsets("0=10\n 3 = 20-30 \n\n", 5)
+
+		endOffset := int64(30)
+		assert.NoError(t, err)
+		assert.Equal(t, map[int]kadmin.PartitionOffset{
+			0: {Start: 10},
+			3: {Start: 20, End: &endOffset},
+		}, offsets)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tests := map[string]string{
+			"":         "enter at least one partition offset",
+			"0":        "'0' is not in the format partition=start or partition=start-end",
+			"a=1":      "'a' is not a valid partition, expected 0 to 4",
+			"0=a":      "'a' is not a valid start offset",
+			"0=5-4":    "'4' is not a valid end offset, it must not be before the start offset",
+			"0=1\n0=2": "partition 0 is entered more than once",
+		}
+		for input, expectedErr := range tests {
+			_, err := parsePartitionOffsets(input, 5)
+
+			assert.EqualError(t, err, expectedErr, input)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package consumption_page
 
 import (
+	"errors"
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"ktea/kadmin"
 	"ktea/kontext"
@@ -9,10 +11,12 @@ import (
 	"ktea/ui/components/notifier"
 	"ktea/ui/components/statusbar"
 	"ktea/ui/pages/nav"
+	"strings"
 )
 
 type ConsumptionCmdBar struct {
 	notifierWidget cmdbar.CmdBar
+	exportWidget   *cmdbar.InputCmdBar
 	active         cmdbar.CmdBar
 }
 
@@ -24,6 +28,14 @@ func (c *ConsumptionCmdBar) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer)
 }
 
 func (c *ConsumptionCmdBar) Update(msg tea.Msg) tea.Cmd {
+	if c.active == c.exportWidget {
+		active, _, cmd := c.exportWidget.Update(msg)
+		if !active {
+			c.active = nil
+		}
+		return cmd
+	}
+
 	// when notifier is active it is receiving priority to handle messages
 	// until a message comes in that deactivates the notifier
 	if c.active == c.notifierWidget {
@@ -36,7 +48,11 @@ func (c *ConsumptionCmdBar) Update(msg tea.Msg) tea.Cmd {
 	}
 
 	switch msg := msg.(type) {
-	case kadmin.ReadingStartedMsg:
+	case kadmin.ReadingStartedMsg,
+		kadmin.KAdminErrorMsg,
+		ExportRequestedMsg,
+		RecordsExportedMsg,
+		ExportFailedMsg:
 		c.active = c.notifierWidget
 		_, _, cmd := c.active.Update(msg)
 		return cmd
@@ -45,6 +61,26 @@ func (c *ConsumptionCmdBar) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// StartExport asks for the path to export the records to, suggesting path.
+func (c *ConsumptionCmdBar) StartExport(path string, recordCount int) {
+	c.exportWidget.Activate(
+		"Export to",
+		fmt.Sprintf("exports %d records as JSON Lines, or as CSV when the file has a .csv extension", recordCount),
+		path,
+		func(path string) (tea.Msg, error) {
+			if strings.TrimSpace(path) == "" {
+				return nil, errors.New("path is required")
+			}
+			return ExportRequestedMsg{Path: path}, nil
+		},
+	)
+	c.active = c.exportWidget
+}
+
+func (c *ConsumptionCmdBar) IsExporting() bool {
+	return c.active == c.exportWidget
+}
+
 func (c *ConsumptionCmdBar) Shortcuts() []statusbar.Shortcut {
 	if c.active == nil {
 		return nil
@@ -63,11 +99,40 @@ func NewConsumptionCmdbar() *ConsumptionCmdBar {
 		m.Idle()
 		return false, nil
 	}
+	consumptionFailedNotifier := func(msg kadmin.KAdminErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Consumption failed", msg.Error)
+		return true, nil
+	}
+	consumptionPausedNotifier := func(msg ConsumptionPausedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.Idle()
+		return true, nil
+	}
+	consumptionResumedNotifier := func(msg ConsumptionResumedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Consuming")
+	}
+	exportRequestedNotifier := func(msg ExportRequestedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Exporting records")
+	}
+	recordsExportedNotifier := func(msg RecordsExportedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowSuccessMsg(fmt.Sprintf("Exported %d records to %s", msg.Count, msg.Path))
+		return true, m.AutoHideCmd()
+	}
+	exportFailedNotifier := func(msg ExportFailedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Export failed", msg.Err)
+		return true, m.AutoHideCmd()
+	}
 	notifierCmdBar := cmdbar.NewNotifierCmdBar()
 	cmdbar.WithMsgHandler(notifierCmdBar, readingStartedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, consumptionFailedNotifier)
 	cmdbar.WithMsgHandler(notifierCmdBar, consumptionEndedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, consumptionPausedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, consumptionResumedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, exportRequestedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, recordsExportedNotifier)
+	cmdbar.WithMsgHandler(notifierCmdBar, exportFailedNotifier)
 	cmdbar.WithMsgHandler(notifierCmdBar, c)
 	return &ConsumptionCmdBar{
 		notifierWidget: notifierCmdBar,
+		exportWidget:   cmdbar.NewInputCmdBar(),
 	}
 }
@@ -2,6 +2,7 @@ package consumption_page
 
 import (
 	"context"
+	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -11,9 +12,15 @@ import (
 	"ktea/ui"
 	"ktea/ui/components/statusbar"
 	"ktea/ui/pages/nav"
+	"slices"
 	"strconv"
+	"time"
 )
 
+// maxRecords bounds the records kept in memory, when exceeded the oldest records are dropped.
+// This keeps long running follows from growing without limit.
+const maxRecords = 5000
+
 type Model struct {
 	table              *table.Model
 	cmdBar             *ConsumptionCmdBar
@@ -26,6 +33,11 @@ type Model struct {
 	readDetails        kadmin.ReadDetails
 	consuming          bool
 	noRecordsAvailable bool
+	paused             bool
+	// waiting is true while waiting for the next record to arrive
+	waiting bool
+	// heldRecord is a record that arrived while paused
+	heldRecord *kadmin.ConsumerRecord
 }
 
 type ConsumerRecordReceived struct {
@@ -34,6 +46,10 @@ type ConsumerRecordReceived struct {
 
 type ConsumptionEndedMsg struct{}
 
+type ConsumptionPausedMsg struct{}
+
+type ConsumptionResumedMsg struct{}
+
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 	var views []string
 	views = append(views, m.cmdBar.View(ktx, renderer))
@@ -58,6 +74,11 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
 
+	// while exporting keys are meant for the path input
+	if _, isKeyMsg := msg.(tea.KeyMsg); isKeyMsg && m.cmdBar.IsExporting() {
+		return m.cmdBar.Update(msg)
+	}
+
 	cmd := m.cmdBar.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -69,7 +90,12 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		} else if msg.String() == "f2" {
 			m.cancelConsumption()
 			m.consuming = false
+			m.paused = false
 			cmds = append(cmds, ui.PublishMsg(ConsumptionEndedMsg{}))
+		} else if msg.String() == "f3" && m.consuming && m.readDetails.Follow {
+			cmds = append(cmds, m.togglePause())
+		} else if msg.String() == "f4" && len(m.records) > 0 {
+			m.cmdBar.StartExport(m.defaultExportPath(), len(m.records))
 		} else if msg.String() == "enter" {
 			if len(m.records) > 0 {
 				selectedRow := m.records[len(m.records)-m.table.Cursor()-1]
@@ -86,6 +112,9 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		}
 	case kadmin.EmptyTopicMsg:
 		m.noRecordsAvailable = true
+	case kadmin.KAdminErrorMsg:
+		m.consuming = false
+		m.waiting = false
 	case kadmin.ReadingStartedMsg:
 		m.consuming = true
 		m.consumerRecordChan = msg.ConsumerRecord
@@ -93,32 +122,75 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		cmds = append(cmds, m.waitForActivity())
 	case ConsumptionEndedMsg:
 		m.consuming = false
+		m.waiting = false
 		return nil
+	case ExportRequestedMsg:
+		records := slices.Clone(m.records)
+		cmds = append(cmds, func() tea.Msg {
+			return exportRecords(records, msg.Path)
+		})
 	case ConsumerRecordReceived:
-		var key string
-		if msg.Record.Key == "" {
-			key = "<null>"
-		} else {
-			key = msg.Record.Key
+		m.waiting = false
+		if m.paused {
+			// stop taking records from the channel, which halts reading until resumed
+			m.heldRecord = &msg.Record
+			return nil
 		}
-		m.records = append(m.records, msg.Record)
-		m.rows = append(
-			[]table.Row{
-				{
-					key,
-					strconv.FormatInt(msg.Record.Partition, 10),
-					strconv.FormatInt(msg.Record.Offset, 10),
-				},
-			},
-			m.rows...,
-		)
+		m.addRecord(msg.Record)
 		return m.waitForActivity()
 	}
 
 	return tea.Batch(cmds...)
 }
 
+func (m *Model) defaultExportPath() string {
+	return fmt.Sprintf("%s-%s.jsonl", m.readDetails.Topic.Name, time.Now().Format("20060102-150405"))
+}
+
+func (m *Model) togglePause() tea.Cmd {
+	if !m.paused {
+		m.paused = true
+		return ui.PublishMsg(ConsumptionPausedMsg{})
+	}
+
+	m.paused = false
+	cmds := []tea.Cmd{ui.PublishMsg(ConsumptionResumedMsg{})}
+	if m.heldRecord != nil {
+		m.addRecord(*m.heldRecord)
+		m.heldRecord = nil
+	}
+	if !m.waiting {
+		cmds = append(cmds, m.waitForActivity())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) addRecord(record kadmin.ConsumerRecord) {
+	var key string
+	if record.Key == "" {
+		key = "<null>"
+	} else {
+		key = record.Key
+	}
+	m.records = append(m.records, record)
+	m.rows = append(
+		[]table.Row{
+			{
+				key,
+				strconv.FormatInt(record.Partition, 10),
+				strconv.FormatInt(record.Offset, 10),
+			},
+		},
+		m.rows...,
+	)
+	if len(m.records) > maxRecords {
+		m.records = m.records[len(m.records)-maxRecords:]
+		m.rows = m.rows[:maxRecords]
+	}
+}
+
 func (m *Model) waitForActivity() tea.Cmd {
+	m.waiting = true
 	return func() tea.Msg {
 		for {
 			select {
@@ -128,17 +200,32 @@ func (m *Model) waitForActivity() tea.Cmd {
 				}
 				return ConsumerRecordReceived{Record: record}
 			case err := <-m.errChan:
-				return err
+				return kadmin.KAdminErrorMsg{Error: err}
 			}
 		}
 	}
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
-	if m.consuming {
+	if m.cmdBar.IsExporting() {
+		return m.cmdBar.Shortcuts()
+	} else if m.consuming && m.readDetails.Follow {
+		pauseShortcut := statusbar.Shortcut{"Pause", "F3"}
+		if m.paused {
+			pauseShortcut = statusbar.Shortcut{"Resume", "F3"}
+		}
+		return []statusbar.Shortcut{
+			{"View Record", "enter"},
+			{"Stop consuming", "F2"},
+			pauseShortcut,
+			{"Export", "F4"},
+			{"Go Back", "esc"},
+		}
+	} else if m.consuming {
 		return []statusbar.Shortcut{
 			{"View Record", "enter"},
 			{"Stop consuming", "F2"},
+			{"Export", "F4"},
 			{"Go Back", "esc"},
 		}
 	} else if m.noRecordsAvailable {
@@ -148,6 +235,7 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 	} else {
 		return []statusbar.Shortcut{
 			{"View Record", "enter"},
+			{"Export", "F4"},
 			{"Go Back", "esc"},
 		}
 	}
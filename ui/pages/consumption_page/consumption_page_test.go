@@ -1,11 +1,18 @@
 package consumption_page
 
 import (
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"ktea/kadmin"
+	"ktea/tests"
+	"ktea/tests/keys"
 	"ktea/ui"
 	"ktea/ui/components/statusbar"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 )
 
 func TestConsumptionPage(t *testing.T) {
@@ -20,4 +27,176 @@ func TestConsumptionPage(t *testing.T) {
 
 		assert.Equal(t, []statusbar.Shortcut{{"Go Back", "esc"}}, m.Shortcuts())
 	})
+
+	t.Run("Pause and resume a follow", func(t *testing.T) {
+		m, _ := New(nil, kadmin.ReadDetails{
+			Topic:  &kadmin.Topic{Name: "topic1"},
+			Follow: true,
+		})
+		records := make(chan kadmin.ConsumerRecord, 1)
+		m.Update(kadmin.ReadingStartedMsg{
+			ConsumerRecord: records,
+			Err:            make(chan error),
+			CancelFunc:     func() {},
+		})
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-1", Offset: 1}})
+
+		assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{"Pause", "F3"})
+
+		m.Update(keys.Key(tea.KeyF3))
+		// arrived while paused
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-2", Offset: 2}})
+
+		assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{"Resume", "F3"})
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "key-1")
+		assert.NotContains(t, render, "key-2")
+
+		cmd := m.Update(keys.Key(tea.KeyF3))
+		records <- kadmin.ConsumerRecord{Key: "key-3", Offset: 3}
+		for _, msg := range tests.ExecuteBatchCmd(cmd) {
+			m.Update(msg)
+		}
+
+		assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{"Pause", "F3"})
+		render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "key-2")
+		assert.Contains(t, render, "key-3")
+	})
+
+	t.Run("Keeps a bounded window of records", func(t *testing.T) {
+		m, _ := New(nil, kadmin.ReadDetails{
+			Topic:  &kadmin.Topic{Name: "topic1"},
+			Follow: true,
+		})
+
+		for i := 0; i < maxRecords+10; i++ {
+			m.(*Model).addRecord(kadmin.ConsumerRecord{Offset: int64(i)})
+		}
+
+		model := m.(*Model)
+		assert.Len(t, model.records, maxRecords)
+		assert.Len(t, model.rows, maxRecords)
+		assert.Equal(t, int64(10), model.records[0].Offset)
+		assert.Equal(t, strconv.Itoa(maxRecords+9), model.rows[0][2])
+	})
+
+	t.Run("Export records to a file", func(t *testing.T) {
+		m, _ := New(nil, kadmin.ReadDetails{
+			Topic: &kadmin.Topic{Name: "topic1"},
+		})
+		timestamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-1", Value: "value-1", Offset: 1, Timestamp: timestamp}})
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-2", Value: "value-2", Offset: 2, Timestamp: timestamp}})
+
+		m.Update(keys.Key(tea.KeyF4))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Export to")
+		assert.Contains(t, render, "topic1-")
+		assert.Contains(t, m.Shortcuts(), statusbar.Shortcut{"Cancel", "esc"})
+
+		path := filepath.Join(t.TempDir(), "records.csv")
+		m.Update(keys.Key(tea.KeyCtrlU))
+		keys.UpdateKeys(m, path)
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		for _, msg := range tests.ExecuteBatchCmd(cmd) {
+			for _, msg := range tests.ExecuteBatchCmd(m.Update(msg)) {
+				m.Update(msg)
+			}
+		}
+
+		render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Exported 2 records to")
+		assert.Contains(t, render, path)
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "Key,Value,Partition,Offset,Timestamp,Headers\n"+
+			"key-1,value-1,0,1,2025-01-02T03:04:05Z,\n"+
+			"key-2,value-2,0,2,2025-01-02T03:04:05Z,\n", string(content))
+	})
+
+	t.Run("Export requires a path", func(t *testing.T) {
+		m, _ := New(nil, kadmin.ReadDetails{
+			Topic: &kadmin.Topic{Name: "topic1"},
+		})
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-1", Offset: 1}})
+		m.Update(keys.Key(tea.KeyF4))
+		m.Update(keys.Key(tea.KeyCtrlU))
+
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+
+		assert.Nil(t, cmd)
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "path is required")
+	})
+
+	t.Run("Esc cancels an export", func(t *testing.T) {
+		m, _ := New(nil, kadmin.ReadDetails{
+			Topic: &kadmin.Topic{Name: "topic1"},
+		})
+		m.Update(ConsumerRecordReceived{Record: kadmin.ConsumerRecord{Key: "key-1", Offset: 1}})
+		m.Update(keys.Key(tea.KeyF4))
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Nil(t, cmd)
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "Export to")
+	})
+}
+
+func TestExportRecords(t *testing.T) {
+	records := []kadmin.ConsumerRecord{
+		{
+			Key:       "key-1",
+			Value:     `{"id":1}`,
+			RawKey:    []byte("key-1"),
+			RawValue:  []byte(`{"id":1}`),
+			Partition: 2,
+			Offset:    10,
+			Headers:   []kadmin.Header{{Key: "h1", Value: "v1"}},
+			Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		{
+			Key:       "key,2",
+			Value:     "plain",
+			RawKey:    []byte("key,2"),
+			RawValue:  []byte("plain"),
+			Partition: 0,
+			Offset:    11,
+			Timestamp: time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC),
+		},
+	}
+
+	t.Run("as JSON Lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "records.jsonl")
+
+		msg := exportRecords(records, path)
+
+		assert.Equal(t, RecordsExportedMsg{Path: path, Count: 2}, msg)
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"key":"a2V5LTE=","value":"eyJpZCI6MX0=","headers":[{"key":"h1","value":"djE="}],"partition":2,"offset":10,"timestamp":"2025-01-02T03:04:05Z"}
+{"key":"a2V5LDI=","value":"cGxhaW4=","headers":[],"partition":0,"offset":11,"timestamp":"2025-01-02T03:04:06Z"}
+`, string(content))
+	})
+
+	t.Run("as CSV", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "records.CSV")
+
+		exportRecords(records, path)
+
+		content, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "Key,Value,Partition,Offset,Timestamp,Headers\n"+
+			"key-1,\"{\"\"id\"\":1}\",2,10,2025-01-02T03:04:05Z,h1=v1\n"+
+			"\"key,2\",plain,0,11,2025-01-02T03:04:06Z,\n", string(content))
+	})
+
+	t.Run("to a directory that does not exist", func(t *testing.T) {
+		msg := exportRecords(records, filepath.Join(t.TempDir(), "missing", "records.jsonl"))
+
+		assert.IsType(t, ExportFailedMsg{}, msg)
+	})
 }
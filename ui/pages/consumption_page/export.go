@@ -0,0 +1,91 @@
+package consumption_page
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	tea "github.com/charmbracelet/bubbletea"
+	"io"
+	"ktea/kadmin"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExportRequestedMsg struct {
+	Path string
+}
+
+type RecordsExportedMsg struct {
+	Path  string
+	Count int
+}
+
+type ExportFailedMsg struct {
+	Err error
+}
+
+// exportRecords writes the records to the file at path, as CSV when it has a .csv extension
+// and as JSON Lines otherwise.
+func exportRecords(records []kadmin.ConsumerRecord, path string) tea.Msg {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return ExportFailedMsg{err}
+	}
+
+	file, err := os.Create(absPath)
+	if err != nil {
+		return ExportFailedMsg{err}
+	}
+
+	if strings.EqualFold(filepath.Ext(absPath), ".csv") {
+		err = writeCsv(file, records)
+	} else {
+		err = writeJsonLines(file, records)
+	}
+	// closing flushes the file, so a failure means the export is incomplete
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ExportFailedMsg{err}
+	}
+	return RecordsExportedMsg{Path: absPath, Count: len(records)}
+}
+
+func writeJsonLines(w io.Writer, records []kadmin.ConsumerRecord) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err := encoder.Encode(kadmin.NewRecordLine(record)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCsv writes the deserialized records, headers are written as one key=value line per header.
+func writeCsv(w io.Writer, records []kadmin.ConsumerRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Key", "Value", "Partition", "Offset", "Timestamp", "Headers"}); err != nil {
+		return err
+	}
+	for _, record := range records {
+		headers := make([]string, 0, len(record.Headers))
+		for _, header := range record.Headers {
+			headers = append(headers, header.Key+"="+header.Value)
+		}
+		if err := writer.Write([]string{
+			record.Key,
+			record.Value,
+			strconv.FormatInt(record.Partition, 10),
+			strconv.FormatInt(record.Offset, 10),
+			record.Timestamp.Format(time.RFC3339Nano),
+			strings.Join(headers, "\n"),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,319 @@
+package copy_page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"strings"
+)
+
+type state int
+
+const (
+	entering state = iota
+	copying
+	copied
+)
+
+// maxFailuresShown limits the failures listed, the most recent ones are shown.
+const maxFailuresShown = 10
+
+type Model struct {
+	state          state
+	form           *huh.Form
+	notifier       *cmdbar.NotifierCmdBar
+	reader         kadmin.RecordReader
+	publisher      kadmin.Publisher
+	kaInstantiator kadmin.Instantiator
+	readDetails    kadmin.ReadDetails
+	ktx            *kontext.ProgramKtx
+	formValues     *formValues
+	started        *kadmin.CopyStartedMsg
+	progress       kadmin.CopyProgressMsg
+	failures       []kadmin.RecordPublicationFailure
+}
+
+type formValues struct {
+	cluster string
+	topic   string
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{m.notifier.View(ktx, renderer)}
+
+	if m.state == entering {
+		views = append(views, renderer.RenderWithStyle(m.form.View(), styles.Form))
+	} else if m.started != nil {
+		views = append(views, renderer.RenderWithStyle(m.progressView(ktx.WindowWidth/2), styles.Form))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) progressView(width int) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(
+		"Copying %s to %s on %s\n\n",
+		m.readDetails.Topic.Name,
+		m.formValues.topic,
+		m.formValues.cluster,
+	))
+	builder.WriteString(fmt.Sprintf("Read %d records\n", m.progress.Read))
+	builder.WriteString(fmt.Sprintf(
+		"Published %d of %d records, %d failed\n\n",
+		m.progress.Published,
+		m.progress.Read,
+		m.progress.Failed,
+	))
+
+	filled := 0
+	if m.progress.Read > 0 {
+		filled = width * (m.progress.Published + m.progress.Failed) / m.progress.Read
+	}
+	builder.WriteString(strings.Repeat("█", filled))
+	builder.WriteString(strings.Repeat("░", width-filled))
+	builder.WriteString("\n")
+
+	if len(m.failures) > 0 {
+		builder.WriteString("\nFailures:\n")
+		failures := m.failures
+		if len(failures) > maxFailuresShown {
+			builder.WriteString(fmt.Sprintf("… %d more\n", len(failures)-maxFailuresShown))
+			failures = failures[len(failures)-maxFailuresShown:]
+		}
+		for _, failure := range failures {
+			builder.WriteString(fmt.Sprintf("record %d: %s\n", failure.Index+1, failure.Err))
+		}
+	}
+	return builder.String()
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return tea.Batch(cmds...)
+	case kadmin.KAdminErrorMsg, kadmin.EmptyTopicMsg:
+		m.initForm()
+		return tea.Batch(cmds...)
+	case kadmin.CopyStartedMsg:
+		m.started = &msg
+		cmds = append(cmds, msg.AwaitProgress)
+		return tea.Batch(cmds...)
+	case kadmin.CopyProgressMsg:
+		m.progress = msg
+		m.failures = append(m.failures, msg.Failures...)
+		cmds = append(cmds, m.started.AwaitProgress)
+		return tea.Batch(cmds...)
+	case kadmin.CopyEndedMsg:
+		m.state = copied
+		return tea.Batch(cmds...)
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			if m.started != nil {
+				m.started.CancelFunc()
+			}
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{Refresh: m.state == copied})
+		}
+	}
+
+	if m.state != entering {
+		return tea.Batch(cmds...)
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	cmds = append(cmds, cmd)
+
+	if m.form.State == huh.StateCompleted {
+		m.state = copying
+		m.started = nil
+		m.progress = kadmin.CopyProgressMsg{}
+		m.failures = nil
+		cmds = append(cmds, m.copyRecords(*m.formValues))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) copyRecords(values formValues) tea.Cmd {
+	return func() tea.Msg {
+		publisher, release, err := m.targetPublisher(values.cluster)
+		if err != nil {
+			return kadmin.KAdminErrorMsg{Error: err}
+		}
+		msg := kadmin.CopyRecords(context.Background(), m.reader, publisher, kadmin.CopyDetails{
+			ReadDetails: m.readDetails,
+			TargetTopic: values.topic,
+		})
+		if started, ok := msg.(kadmin.CopyStartedMsg); ok {
+			// also when cancelled, as the page is left without waiting for the copy to end
+			go func() {
+				<-started.Done
+				release()
+			}()
+		} else {
+			release()
+		}
+		return msg
+	}
+}
+
+// targetPublisher connects to the target cluster, unless it is the active one. The returned
+// release func closes the connection once the publisher is no longer used.
+func (m *Model) targetPublisher(clusterName string) (kadmin.Publisher, func(), error) {
+	if clusterName == m.activeClusterName() {
+		return m.publisher, func() {}, nil
+	}
+	cluster := m.ktx.Config.FindClusterByName(clusterName)
+	if cluster == nil {
+		return nil, nil, fmt.Errorf("cluster %s not found", clusterName)
+	}
+	target, err := m.kaInstantiator(kadmin.ToConnectionDetails(cluster))
+	if err != nil {
+		return nil, nil, err
+	}
+	return target, func() {
+		if err := target.Close(); err != nil {
+			log.Warn("Unable to close connection to target cluster", "cluster", clusterName, "err", err)
+		}
+	}, nil
+}
+
+func (m *Model) activeClusterName() string {
+	if m.ktx.Config == nil || m.ktx.Config.ActiveCluster() == nil {
+		return ""
+	}
+	return m.ktx.Config.ActiveCluster().Name
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	switch m.state {
+	case copying:
+		return []statusbar.Shortcut{
+			{"Cancel", "esc"},
+		}
+	case copied:
+		return []statusbar.Shortcut{
+			{"Go Back", "esc"},
+		}
+	}
+	return []statusbar.Shortcut{
+		{"Confirm", "enter"},
+		{"Next Field", "tab"},
+		{"Prev. Field", "s-tab"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.readDetails.Topic.Name + " / Copy"
+}
+
+func (m *Model) initForm() {
+	m.state = entering
+
+	var clusterOptions []huh.Option[string]
+	if m.ktx.Config != nil {
+		for _, cluster := range m.ktx.Config.Clusters {
+			clusterOptions = append(clusterOptions, huh.NewOption(cluster.Name, cluster.Name))
+		}
+	}
+	clusterSelect := huh.NewSelect[string]().
+		Title("Target cluster").
+		Options(clusterOptions...).
+		Value(&m.formValues.cluster)
+	topicInput := huh.NewInput().
+		Title("Target topic").
+		Description("Records are copied as is, keeping their key, headers and timestamp.").
+		Value(&m.formValues.topic).
+		Validate(func(topic string) error {
+			if topic == "" {
+				return errors.New("target topic cannot be empty")
+			}
+			if topic == m.readDetails.Topic.Name && m.formValues.cluster == m.activeClusterName() {
+				return errors.New("records cannot be copied onto the topic they are read from")
+			}
+			return nil
+		})
+
+	form := huh.NewForm(huh.NewGroup(clusterSelect, topicInput))
+	form.QuitAfterSubmit = false
+	form.Init()
+	m.form = form
+}
+
+func New(
+	reader kadmin.RecordReader,
+	publisher kadmin.Publisher,
+	kaInstantiator kadmin.Instantiator,
+	readDetails kadmin.ReadDetails,
+	ktx *kontext.ProgramKtx,
+) *Model {
+	m := &Model{
+		reader:         reader,
+		publisher:      publisher,
+		kaInstantiator: kaInstantiator,
+		readDetails:    readDetails,
+		ktx:            ktx,
+		formValues: &formValues{
+			topic: readDetails.Topic.Name,
+		},
+	}
+	m.formValues.cluster = m.activeClusterName()
+	m.initForm()
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.KAdminErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Copy failed", msg.Error)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.EmptyTopicMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Nothing to copy", errors.New("no records selected"))
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.CopyStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithRocketMsg("Copying records")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.CopyProgressMsg, n *notifier.Model) (bool, tea.Cmd) {
+		if msg.Err != nil {
+			n.ShowErrorMsg("Reading records failed", msg.Err)
+		}
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.CopyEndedMsg, n *notifier.Model) (bool, tea.Cmd) {
+		if m.progress.Err != nil {
+			return true, nil
+		}
+		if m.progress.Failed > 0 {
+			n.ShowErrorMsg(
+				"Copy finished",
+				fmt.Errorf("%d of %d records failed", m.progress.Failed, m.progress.Read),
+			)
+			return true, nil
+		}
+		n.ShowSuccessMsg(fmt.Sprintf("Copied %d records!", m.progress.Published))
+		return true, n.AutoHideCmd()
+	})
+	m.notifier = notifierCmdBar
+
+	return m
+}
@@ -0,0 +1,203 @@
+package copy_page
+
+import (
+	"context"
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/config"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+	"time"
+)
+
+type MockRecordReader struct {
+	ReadRecordsFunc func(ctx context.Context, rd kadmin.ReadDetails) tea.Msg
+}
+
+func (m *MockRecordReader) ReadRecords(ctx context.Context, rd kadmin.ReadDetails) tea.Msg {
+	return m.ReadRecordsFunc(ctx, rd)
+}
+
+type ClosingKadmin struct {
+	kadmin.MockKadmin
+	closed chan struct{}
+}
+
+func (k *ClosingKadmin) Close() error {
+	close(k.closed)
+	return nil
+}
+
+func awaitClosed(t *testing.T, target *ClosingKadmin) {
+	select {
+	case <-target.closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection to target cluster not closed")
+	}
+}
+
+func testKontext() *kontext.ProgramKtx {
+	ktx := ui.NewTestKontext()
+	ktx.Config = &config.Config{
+		Clusters: []config.Cluster{
+			{Name: "prd", Active: true},
+			{Name: "stg"},
+		},
+	}
+	return ktx
+}
+
+var readDetails = kadmin.ReadDetails{
+	Topic:      &kadmin.Topic{Name: "orders"},
+	StartPoint: kadmin.MostRecent,
+	Limit:      500,
+}
+
+func TestCopyPage(t *testing.T) {
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m := New(nil, kadmin.NewMockKadmin(), kadmin.NewMockKadminInstantiator(), readDetails, testKontext())
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+
+	t.Run("cannot copy onto the source topic", func(t *testing.T) {
+		m := New(nil, kadmin.NewMockKadmin(), kadmin.NewMockKadminInstantiator(), readDetails, testKontext())
+		m.View(testKontext(), ui.TestRenderer)
+
+		// active cluster
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+		// source topic
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(testKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "records cannot be copied onto the topic they are read from")
+	})
+
+	t.Run("copy to another cluster", func(t *testing.T) {
+		var readWith kadmin.ReadDetails
+		reader := &MockRecordReader{ReadRecordsFunc: func(ctx context.Context, rd kadmin.ReadDetails) tea.Msg {
+			readWith = rd
+			return kadmin.EmptyTopicMsg{}
+		}}
+		var connectedTo kadmin.ConnectionDetails
+		instantiator := func(cd kadmin.ConnectionDetails) (kadmin.Kadmin, error) {
+			connectedTo = cd
+			return kadmin.NewMockKadmin(), nil
+		}
+		ktx := testKontext()
+		ktx.Config.Clusters[1].BootstrapServers = []string{"stg:9092"}
+		m := New(reader, kadmin.NewMockKadmin(), instantiator, readDetails, ktx)
+		m.View(ktx, ui.TestRenderer)
+
+		// staging cluster
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+		// source topic
+		msgs := keys.Submit(m)
+		for _, msg := range msgs {
+			m.Update(msg)
+		}
+
+		assert.Contains(t, msgs, kadmin.EmptyTopicMsg{})
+		assert.Equal(t, []string{"stg:9092"}, connectedTo.BootstrapServers)
+		assert.Equal(t, readDetails, readWith)
+		render := m.View(ktx, ui.TestRenderer)
+		assert.Contains(t, render, "Nothing to copy")
+	})
+
+	t.Run("close the connection to the target cluster once copied", func(t *testing.T) {
+		records := make(chan kadmin.ConsumerRecord)
+		close(records)
+		reader := &MockRecordReader{ReadRecordsFunc: func(ctx context.Context, rd kadmin.ReadDetails) tea.Msg {
+			return kadmin.ReadingStartedMsg{ConsumerRecord: records, Err: make(chan error), CancelFunc: func() {}}
+		}}
+		target := &ClosingKadmin{closed: make(chan struct{})}
+		instantiator := func(cd kadmin.ConnectionDetails) (kadmin.Kadmin, error) {
+			return target, nil
+		}
+		m := New(reader, kadmin.NewMockKadmin(), instantiator, readDetails, testKontext())
+		m.View(testKontext(), ui.TestRenderer)
+
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+		msgs := keys.Submit(m)
+
+		started := msgs[len(msgs)-1].(kadmin.CopyStartedMsg)
+		for started.AwaitProgress() != (kadmin.CopyEndedMsg{}) {
+		}
+		awaitClosed(t, target)
+	})
+
+	t.Run("close the connection to the target cluster when there is nothing to copy", func(t *testing.T) {
+		reader := &MockRecordReader{ReadRecordsFunc: func(ctx context.Context, rd kadmin.ReadDetails) tea.Msg {
+			return kadmin.EmptyTopicMsg{}
+		}}
+		target := &ClosingKadmin{closed: make(chan struct{})}
+		instantiator := func(cd kadmin.ConnectionDetails) (kadmin.Kadmin, error) {
+			return target, nil
+		}
+		m := New(reader, kadmin.NewMockKadmin(), instantiator, readDetails, testKontext())
+		m.View(testKontext(), ui.TestRenderer)
+
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+		keys.Submit(m)
+
+		awaitClosed(t, target)
+	})
+
+	t.Run("failing to connect to the target cluster", func(t *testing.T) {
+		instantiator := func(cd kadmin.ConnectionDetails) (kadmin.Kadmin, error) {
+			return nil, errors.New("connection refused")
+		}
+		m := New(nil, kadmin.NewMockKadmin(), instantiator, readDetails, testKontext())
+		m.View(testKontext(), ui.TestRenderer)
+
+		m.Update(keys.Key(tea.KeyDown))
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+		for _, msg := range keys.Submit(m) {
+			m.Update(msg)
+		}
+
+		render := m.View(testKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Copy failed")
+		assert.Contains(t, render, "connection refused")
+	})
+
+	t.Run("shows progress and failures", func(t *testing.T) {
+		m := New(nil, kadmin.NewMockKadmin(), kadmin.NewMockKadminInstantiator(), readDetails, testKontext())
+		m.View(testKontext(), ui.TestRenderer)
+		m.state = copying
+		m.formValues.topic = "orders-copy"
+
+		m.Update(kadmin.CopyStartedMsg{Progress: make(chan kadmin.CopyProgressMsg), CancelFunc: func() {}})
+		m.Update(kadmin.CopyProgressMsg{
+			Read:      3,
+			Published: 2,
+			Failed:    1,
+			Failures:  []kadmin.RecordPublicationFailure{{Index: 2, Err: errors.New("record too large")}},
+		})
+
+		render := m.View(testKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Copying orders to orders-copy on prd")
+		assert.Contains(t, render, "Published 2 of 3 records, 1 failed")
+		assert.Contains(t, render, "record 3: record too large")
+
+		m.Update(kadmin.CopyEndedMsg{})
+
+		render = m.View(testKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "1 of 3 records failed")
+	})
+}
@@ -0,0 +1,182 @@
+package create_partitions_page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"strconv"
+)
+
+type state int
+
+const (
+	entering state = iota
+	creating
+	created
+)
+
+type Model struct {
+	state      state
+	form       *huh.Form
+	notifier   *cmdbar.NotifierCmdBar
+	creator    kadmin.PartitionCreator
+	topic      *kadmin.Topic
+	formValues *formValues
+}
+
+type formValues struct {
+	numPartitions string
+	confirmed     bool
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{m.notifier.View(ktx, renderer)}
+
+	if m.state == entering {
+		views = append(views, renderer.RenderWithStyle(m.form.View(), styles.Form))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return tea.Batch(cmds...)
+	case kadmin.PartitionCreationStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+		return tea.Batch(cmds...)
+	case kadmin.PartitionCreationErrMsg:
+		m.initForm()
+		return tea.Batch(cmds...)
+	case kadmin.PartitionsCreatedMsg:
+		m.state = created
+		return tea.Batch(cmds...)
+	case tea.KeyMsg:
+		if msg.String() == "esc" && m.state != creating {
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{Refresh: m.state == created})
+		}
+	}
+
+	if m.state != entering {
+		return tea.Batch(cmds...)
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	cmds = append(cmds, cmd)
+
+	if m.form.State == huh.StateCompleted {
+		if !m.formValues.confirmed {
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
+		}
+		m.state = creating
+		numPartitions, _ := strconv.Atoi(m.formValues.numPartitions)
+		cmds = append(cmds, func() tea.Msg {
+			return m.creator.CreatePartitions(kadmin.PartitionCreationDetails{
+				Topic:         m.topic.Name,
+				NumPartitions: numPartitions,
+			})
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	switch m.state {
+	case creating:
+		return nil
+	case created:
+		return []statusbar.Shortcut{
+			{"Go Back", "esc"},
+		}
+	}
+	return []statusbar.Shortcut{
+		{"Confirm", "enter"},
+		{"Next Field", "tab"},
+		{"Prev. Field", "s-tab"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Partitions"
+}
+
+func (m *Model) initForm() {
+	m.state = entering
+	m.formValues.confirmed = false
+	numPartitions := huh.NewInput().
+		Title("Partitions").
+		Description(fmt.Sprintf("Currently %d partitions, partitions can only be added.", m.topic.Partitions)).
+		Value(&m.formValues.numPartitions).
+		Validate(func(value string) error {
+			if value == "" {
+				return errors.New("partitions cannot be empty")
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.New("'" + value + "' is not a valid number")
+			}
+			if n <= m.topic.Partitions {
+				return fmt.Errorf("must be more than the current %d partitions", m.topic.Partitions)
+			}
+			return nil
+		})
+	confirm := huh.NewConfirm().
+		Title("Keys will map to other partitions").
+		Description("Records with a key are assigned a partition based on the number of partitions.\n" +
+			"New records might end up on another partition than earlier records with the same key,\n" +
+			"breaking the ordering per key consumers might rely on.").
+		Value(&m.formValues.confirmed).
+		Affirmative("Add partitions").
+		Negative("Cancel")
+
+	form := huh.NewForm(huh.NewGroup(numPartitions, confirm))
+	form.QuitAfterSubmit = false
+	form.Init()
+	m.form = form
+}
+
+func New(creator kadmin.PartitionCreator, topic *kadmin.Topic) *Model {
+	m := &Model{
+		creator:    creator,
+		topic:      topic,
+		formValues: &formValues{},
+	}
+	m.initForm()
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionCreationStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Adding partitions")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionCreationErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to add partitions", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionsCreatedMsg, n *notifier.Model) (bool, tea.Cmd) {
+		n.ShowSuccessMsg(fmt.Sprintf("%s now has %s partitions!", m.topic.Name, m.formValues.numPartitions))
+		return true, nil
+	})
+	m.notifier = notifierCmdBar
+
+	return m
+}
@@ -0,0 +1,123 @@
+package create_partitions_page
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+type MockPartitionCreator struct {
+	details *kadmin.PartitionCreationDetails
+}
+
+func (m *MockPartitionCreator) CreatePartitions(pcd kadmin.PartitionCreationDetails) tea.Msg {
+	m.details = &pcd
+	return kadmin.PartitionCreationStartedMsg{}
+}
+
+// submit enters the partition count and answers the warning about keys mapping to other partitions.
+func submit(m *Model, numPartitions string, confirm bool) []tea.Msg {
+	keys.UpdateKeys(m, numPartitions)
+	cmd := m.Update(keys.Key(tea.KeyEnter))
+	m.Update(cmd())
+
+	if confirm {
+		m.Update(keys.Key(tea.KeyLeft))
+	}
+	return keys.Submit(m)
+}
+
+func TestCreatePartitionsPage(t *testing.T) {
+	topic := &kadmin.Topic{Name: "topic1", Partitions: 3, Replicas: 1}
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+
+	t.Run("shows current partition count and key warning", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "Currently 3 partitions")
+		assert.Contains(t, render, "Keys will map to other partitions")
+	})
+
+	t.Run("partitions must be more than the current count", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		keys.UpdateKeys(m, "3")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "must be more than the current 3 partitions")
+	})
+
+	t.Run("partitions must be a number", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		keys.UpdateKeys(m, "a")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "'a' is not a valid number")
+	})
+
+	t.Run("add partitions when confirmed", func(t *testing.T) {
+		creator := &MockPartitionCreator{}
+		m := New(creator, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		msgs := submit(m, "6", true)
+
+		assert.Contains(t, msgs, kadmin.PartitionCreationStartedMsg{})
+		assert.Equal(t, &kadmin.PartitionCreationDetails{Topic: "topic1", NumPartitions: 6}, creator.details)
+	})
+
+	t.Run("go back when not confirmed", func(t *testing.T) {
+		creator := &MockPartitionCreator{}
+		m := New(creator, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		msgs := submit(m, "6", false)
+
+		assert.Contains(t, msgs, nav.LoadTopicsPageMsg{})
+		assert.Nil(t, creator.details)
+	})
+
+	t.Run("show success and refresh topics when going back", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		submit(m, "6", true)
+
+		m.Update(kadmin.PartitionsCreatedMsg{})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "topic1 now has 6 partitions!")
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+		assert.Equal(t, nav.LoadTopicsPageMsg{Refresh: true}, cmd())
+	})
+
+	t.Run("show error and form again when adding fails", func(t *testing.T) {
+		m := New(&MockPartitionCreator{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		submit(m, "6", true)
+
+		m.Update(kadmin.PartitionCreationErrMsg{Err: errors.New("not authorized")})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Unable to add partitions")
+		assert.Contains(t, render, "Partitions")
+	})
+}
@@ -0,0 +1,279 @@
+package import_page
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"os"
+	"strings"
+)
+
+type state int
+
+const (
+	entering state = iota
+	importing
+	imported
+)
+
+// maxFailuresShown limits the failures listed, the most recent ones are shown.
+const maxFailuresShown = 10
+
+type Model struct {
+	state      state
+	form       *huh.Form
+	notifier   *cmdbar.NotifierCmdBar
+	publisher  kadmin.Publisher
+	topic      *kadmin.Topic
+	formValues *formValues
+	started    *kadmin.BulkPublicationStartedMsg
+	progress   kadmin.BulkPublicationProgressMsg
+	failures   []kadmin.RecordPublicationFailure
+	// cancel stops the running import
+	cancel context.CancelFunc
+}
+
+type formValues struct {
+	path          string
+	keepPartition bool
+	keepTimestamp bool
+}
+
+type ImportFileErrMsg struct {
+	Err error
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{m.notifier.View(ktx, renderer)}
+
+	if m.state == entering {
+		views = append(views, renderer.RenderWithStyle(m.form.View(), styles.Form))
+	} else if m.started != nil {
+		views = append(views, renderer.RenderWithStyle(m.progressView(ktx.WindowWidth/2), styles.Form))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) progressView(width int) string {
+	processed := m.progress.Published + m.progress.Failed
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf(
+		"Published %d of %d records, %d failed\n\n",
+		m.progress.Published,
+		m.started.Total,
+		m.progress.Failed,
+	))
+
+	filled := 0
+	if m.started.Total > 0 {
+		filled = width * processed / m.started.Total
+	}
+	builder.WriteString(strings.Repeat("█", filled))
+	builder.WriteString(strings.Repeat("░", width-filled))
+	builder.WriteString("\n")
+
+	if len(m.failures) > 0 {
+		builder.WriteString("\nFailures:\n")
+		failures := m.failures
+		if len(failures) > maxFailuresShown {
+			builder.WriteString(fmt.Sprintf("… %d more\n", len(failures)-maxFailuresShown))
+			failures = failures[len(failures)-maxFailuresShown:]
+		}
+		for _, failure := range failures {
+			builder.WriteString(fmt.Sprintf("record %d: %s\n", failure.Index+1, failure.Err))
+		}
+	}
+	return builder.String()
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return tea.Batch(cmds...)
+	case ImportFileErrMsg:
+		m.cancel()
+		m.initForm()
+		return tea.Batch(cmds...)
+	case kadmin.BulkPublicationStartedMsg:
+		m.started = &msg
+		m.progress = kadmin.BulkPublicationProgressMsg{Total: msg.Total}
+		cmds = append(cmds, msg.AwaitProgress)
+		return tea.Batch(cmds...)
+	case kadmin.BulkPublicationProgressMsg:
+		m.progress = msg
+		m.failures = append(m.failures, msg.Failures...)
+		cmds = append(cmds, m.started.AwaitProgress)
+		return tea.Batch(cmds...)
+	case kadmin.BulkPublicationEndedMsg:
+		m.state = imported
+		m.cancel()
+		return tea.Batch(cmds...)
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			if m.state == importing {
+				m.cancel()
+			}
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
+		} else if msg.String() == "ctrl+r" && m.state == imported {
+			m.formValues = &formValues{}
+			m.started = nil
+			m.failures = nil
+			m.initForm()
+			return tea.Batch(cmds...)
+		}
+	}
+
+	if m.state != entering {
+		return tea.Batch(cmds...)
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	cmds = append(cmds, cmd)
+
+	if m.form.State == huh.StateCompleted {
+		m.state = importing
+		m.started = nil
+		m.failures = nil
+		ctx, cancel := context.WithCancel(context.Background())
+		m.cancel = cancel
+		cmds = append(cmds, m.importRecords(ctx, *m.formValues))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) importRecords(ctx context.Context, values formValues) tea.Cmd {
+	return func() tea.Msg {
+		file, err := os.Open(values.path)
+		if err != nil {
+			return ImportFileErrMsg{err}
+		}
+		defer file.Close()
+
+		lines, err := kadmin.ReadRecordLines(file)
+		if err != nil {
+			return ImportFileErrMsg{err}
+		}
+		if len(lines) == 0 {
+			return ImportFileErrMsg{errors.New("the file does not contain any records")}
+		}
+
+		records := make([]kadmin.ProducerRecord, 0, len(lines))
+		for _, line := range lines {
+			records = append(records, line.ToProducerRecord(m.topic.Name, values.keepPartition, values.keepTimestamp))
+		}
+		return m.publisher.PublishRecords(ctx, records)
+	}
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	switch m.state {
+	case importing:
+		return []statusbar.Shortcut{
+			{"Cancel", "esc"},
+		}
+	case imported:
+		return []statusbar.Shortcut{
+			{"New Import", "C-r"},
+			{"Go Back", "esc"},
+		}
+	}
+	return []statusbar.Shortcut{
+		{"Confirm", "enter"},
+		{"Next Field", "tab"},
+		{"Prev. Field", "s-tab"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Import"
+}
+
+func (m *Model) initForm() {
+	m.state = entering
+	pathInput := huh.NewInput().
+		Title("File").
+		Description("JSON Lines file as exported from the consumption page, with base64 encoded keys, values and headers.").
+		Value(&m.formValues.path).
+		Validate(func(path string) error {
+			if path == "" {
+				return errors.New("file cannot be empty")
+			}
+			if info, err := os.Stat(path); err != nil {
+				return fmt.Errorf("file %s not found", path)
+			} else if info.IsDir() {
+				return fmt.Errorf("%s is a directory", path)
+			}
+			return nil
+		})
+	keepPartition := huh.NewConfirm().
+		Title("Keep partitions").
+		Description("Publish records to the partition they were consumed from.").
+		Value(&m.formValues.keepPartition).
+		Affirmative("Yes").
+		Negative("No")
+	keepTimestamp := huh.NewConfirm().
+		Title("Keep timestamps").
+		Description("Publish records with the timestamp they were consumed with.").
+		Value(&m.formValues.keepTimestamp).
+		Affirmative("Yes").
+		Negative("No")
+
+	form := huh.NewForm(huh.NewGroup(pathInput, keepPartition, keepTimestamp))
+	form.QuitAfterSubmit = false
+	form.Init()
+	m.form = form
+}
+
+func New(publisher kadmin.Publisher, topic *kadmin.Topic) *Model {
+	m := &Model{
+		publisher:  publisher,
+		topic:      topic,
+		formValues: &formValues{},
+	}
+	m.initForm()
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg ImportFileErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to read records", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.BulkPublicationStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithRocketMsg("Importing records")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.BulkPublicationEndedMsg, n *notifier.Model) (bool, tea.Cmd) {
+		if m.progress.Failed > 0 {
+			n.ShowErrorMsg(
+				"Import finished",
+				fmt.Errorf("%d of %d records failed", m.progress.Failed, m.started.Total),
+			)
+			return true, nil
+		}
+		n.ShowSuccessMsg(fmt.Sprintf("Imported %d records!", m.progress.Published))
+		return true, n.AutoHideCmd()
+	})
+	m.notifier = notifierCmdBar
+
+	return m
+}
@@ -0,0 +1,185 @@
+package import_page
+
+import (
+	"context"
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type MockPublisher struct {
+	PublishRecordsFunc func(records []kadmin.ProducerRecord) kadmin.BulkPublicationStartedMsg
+	ctx                context.Context
+}
+
+func (m *MockPublisher) PublishRecord(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+	return kadmin.PublicationStartedMsg{}
+}
+
+func (m *MockPublisher) PublishRecords(ctx context.Context, records []kadmin.ProducerRecord) kadmin.BulkPublicationStartedMsg {
+	m.ctx = ctx
+	if m.PublishRecordsFunc != nil {
+		return m.PublishRecordsFunc(records)
+	}
+	return kadmin.BulkPublicationStartedMsg{}
+}
+
+func writeRecords(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// submit fills in the form, answering whether to keep partitions and timestamps.
+func submit(m *Model, path string, keep bool) tea.Msg {
+	keys.UpdateKeys(m, path)
+	cmd := m.Update(keys.Key(tea.KeyEnter))
+	m.Update(cmd())
+
+	// keep partitions
+	if keep {
+		m.Update(keys.Key(tea.KeyLeft))
+	}
+	cmd = m.Update(keys.Key(tea.KeyEnter))
+	m.Update(cmd())
+
+	// keep timestamps
+	if keep {
+		m.Update(keys.Key(tea.KeyLeft))
+	}
+	for _, msg := range keys.Submit(m) {
+		switch msg.(type) {
+		case kadmin.BulkPublicationStartedMsg, ImportFileErrMsg:
+			return msg
+		}
+	}
+	return nil
+}
+
+func TestImportPage(t *testing.T) {
+	topic := &kadmin.Topic{Name: "topic1", Partitions: 2}
+	// keys, values and headers are base64 encoded
+	content := `{"key":"a2V5LTE=","value":"dmFsdWUtMQ==","headers":[{"key":"h1","value":"djE="}],"partition":1,"offset":10,"timestamp":"2025-01-02T03:04:05Z"}
+{"key":null,"value":null,"headers":[],"partition":0,"offset":11,"timestamp":"2025-01-02T03:04:06Z"}
+`
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m := New(&MockPublisher{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.IsType(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+
+	t.Run("import records keeping partitions and timestamps", func(t *testing.T) {
+		var published []kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordsFunc: func(records []kadmin.ProducerRecord) kadmin.BulkPublicationStartedMsg {
+				published = records
+				return kadmin.BulkPublicationStartedMsg{Total: len(records)}
+			},
+		}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		msg := submit(m, writeRecords(t, content), true)
+
+		assert.IsType(t, kadmin.BulkPublicationStartedMsg{}, msg)
+		assert.Len(t, published, 2)
+		assert.Equal(t, "topic1", published[0].Topic)
+		assert.Equal(t, []byte("key-1"), published[0].Key)
+		assert.Equal(t, []byte("value-1"), published[0].Value)
+		assert.Equal(t, []kadmin.Header{{Key: "h1", Value: "v1"}}, published[0].Headers)
+		assert.Equal(t, 1, *published[0].Partition)
+		assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), published[0].Timestamp)
+	})
+
+	t.Run("import records without partitions and timestamps", func(t *testing.T) {
+		var published []kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordsFunc: func(records []kadmin.ProducerRecord) kadmin.BulkPublicationStartedMsg {
+				published = records
+				return kadmin.BulkPublicationStartedMsg{Total: len(records)}
+			},
+		}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		submit(m, writeRecords(t, content), false)
+
+		assert.Nil(t, published[1].Partition)
+		assert.True(t, published[1].Timestamp.IsZero())
+		assert.Nil(t, published[1].Key)
+		assert.Nil(t, published[1].Value)
+	})
+
+	t.Run("esc cancels a running import", func(t *testing.T) {
+		publisher := &MockPublisher{}
+		m := New(publisher, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		m.Update(submit(m, writeRecords(t, content), false))
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.IsType(t, nav.LoadTopicsPageMsg{}, cmd())
+		assert.ErrorIs(t, publisher.ctx.Err(), context.Canceled)
+	})
+
+	t.Run("shows progress and failures", func(t *testing.T) {
+		m := New(&MockPublisher{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		m.state = importing
+		m.cancel = func() {}
+
+		progress := make(chan kadmin.BulkPublicationProgressMsg, 1)
+		m.Update(kadmin.BulkPublicationStartedMsg{Progress: progress, Total: 2})
+		m.Update(kadmin.BulkPublicationProgressMsg{
+			Published: 1,
+			Failed:    1,
+			Total:     2,
+			Failures:  []kadmin.RecordPublicationFailure{{Index: 1, Err: errors.New("invalid partition")}},
+		})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Published 1 of 2 records, 1 failed")
+		assert.Contains(t, render, "record 2: invalid partition")
+		assert.Equal(t, []statusbar.Shortcut{{"Cancel", "esc"}}, m.Shortcuts())
+
+		m.Update(kadmin.BulkPublicationEndedMsg{})
+
+		render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "1 of 2 records failed")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		m := New(&MockPublisher{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		msg := submit(m, writeRecords(t, "{\"key\":"), false)
+		m.Update(msg)
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "line 1 is not a valid record")
+		assert.Contains(t, render, "File")
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		m := New(&MockPublisher{}, topic)
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		keys.UpdateKeys(m, "/does/not/exist.jsonl")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "file /does/not/exist.jsonl not found")
+	})
+}
@@ -20,10 +20,38 @@ type LoadCreateTopicPageMsg struct{}
 
 type LoadTopicConfigPageMsg struct{}
 
+type LoadTopicDetailsPageMsg struct {
+	Topic *kadmin.Topic
+}
+
+type LoadTopicCGroupsPageMsg struct {
+	Topic *kadmin.Topic
+}
+
+type LoadReassignPartitionsPageMsg struct {
+	Topic *kadmin.Topic
+}
+
+type LoadCreatePartitionsPageMsg struct {
+	Topic *kadmin.Topic
+}
+
 type LoadPublishPageMsg struct {
 	Topic *kadmin.Topic
 }
 
+type LoadImportPageMsg struct {
+	Topic *kadmin.Topic
+}
+
+type LoadCopyFormPageMsg struct {
+	Topic *kadmin.Topic
+}
+
+type LoadCopyPageMsg struct {
+	ReadDetails kadmin.ReadDetails
+}
+
 type LoadConsumptionPageMsg struct {
 	ReadDetails kadmin.ReadDetails
 }
@@ -48,6 +76,17 @@ type LoadCGroupTopicsPageMsg struct {
 	GroupName string
 }
 
+type LoadCGroupDetailsPageMsg struct {
+	Group *kadmin.ConsumerGroup
+}
+
+type LoadResetOffsetsPageMsg struct {
+	GroupName string
+	// Topic is the selected topic of which the Partitions can be reset individually
+	Topic      string
+	Partitions []int32
+}
+
 type LoadCreateSubjectPageMsg struct{}
 
 type LoadSubjectsPageMsg struct {
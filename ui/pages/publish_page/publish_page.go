@@ -7,8 +7,10 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/serdes"
 	"ktea/styles"
 	"ktea/ui"
 	"ktea/ui/components/notifier"
@@ -32,6 +34,10 @@ type Model struct {
 	topic      *kadmin.Topic
 	notifier   *notifier.Model
 	formValues *formValues
+	// keyFormat and valueFormat are the formats configured for the topic,
+	// the entered key and payload are serialized accordingly
+	keyFormat   config.Format
+	valueFormat config.Format
 }
 
 type LoadPageMsg struct {
@@ -45,17 +51,15 @@ type formValues struct {
 	Headers   string
 }
 
-func (v *formValues) parsedHeaders() map[string]string {
+func (v *formValues) parsedHeaders() []kadmin.Header {
+	headers := []kadmin.Header{}
 	if v.Headers == "" {
-		return map[string]string{}
+		return headers
 	}
-	headers := map[string]string{}
 	for _, line := range strings.Split(v.Headers, "\n") {
 		if strings.Contains(line, "=") {
 			split := strings.Split(line, "=")
-			key := split[0]
-			value := split[1]
-			headers[key] = value
+			headers = append(headers, kadmin.Header{Key: split[0], Value: split[1]})
 		}
 	}
 	return headers
@@ -121,6 +125,16 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		if m.topicForm != nil && m.topicForm.State == huh.StateCompleted {
 			m.state = publishing
 			m.topicForm.State = huh.StateNormal
+			key, err := serializeKey(m.keyFormat, m.formValues.Key)
+			if err != nil {
+				m.state = none
+				return m.notifier.ShowErrorMsg("Invalid key", err)
+			}
+			value, err := serialize(m.valueFormat, m.formValues.Payload)
+			if err != nil {
+				m.state = none
+				return m.notifier.ShowErrorMsg("Invalid payload", err)
+			}
 			return tea.Batch(
 				m.notifier.SpinWithRocketMsg("Publishing record"),
 				func() tea.Msg {
@@ -132,8 +146,8 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 					}
 
 					return m.publisher.PublishRecord(&kadmin.ProducerRecord{
-						Key:       m.formValues.Key,
-						Value:     m.formValues.Payload,
+						Key:       key,
+						Value:     value,
 						Topic:     m.topic.Name,
 						Headers:   m.formValues.parsedHeaders(),
 						Partition: part,
@@ -145,6 +159,30 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+func serialize(format config.Format, data string) ([]byte, error) {
+	serializer, err := serdes.NewFormatSerializer(format)
+	if err != nil {
+		return nil, err
+	}
+	return serializer.Serialize(data)
+}
+
+// serializeKey serializes the key, an empty key is published as a null key.
+func serializeKey(format config.Format, data string) ([]byte, error) {
+	if data == "" {
+		return nil, nil
+	}
+	return serialize(format, data)
+}
+
+// fieldTitle mentions the format the field has to be entered in, when one is configured.
+func fieldTitle(title string, format config.Format) string {
+	if format == config.DefaultFormat {
+		return title
+	}
+	return fmt.Sprintf("%s (%s)", title, format)
+}
+
 func (m *Model) resetForm() {
 	m.state = none
 	m.formValues.Key = ""
@@ -158,12 +196,20 @@ func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
 	payload := huh.NewText().
 		ShowLineNumbers(true).
 		Value(&m.formValues.Payload).
-		Title("Payload").
+		Title(fieldTitle("Payload", m.valueFormat)).
+		Validate(func(str string) error {
+			_, err := serialize(m.valueFormat, str)
+			return err
+		}).
 		WithHeight(ktx.AvailableHeight - 10)
 	key := huh.NewInput().
-		Title("Key").
+		Title(fieldTitle("Key", m.keyFormat)).
 		Description("Leave empty to use a null key for the message.").
-		Value(&m.formValues.Key)
+		Value(&m.formValues.Key).
+		Validate(func(str string) error {
+			_, err := serializeKey(m.keyFormat, str)
+			return err
+		})
 	partition := huh.NewInput().
 		Value(&m.formValues.Partition).
 		Description("Leave empty to use the default hash partitioner.").
@@ -209,11 +255,18 @@ func (m *Model) newForm(ktx *kontext.ProgramKtx) *huh.Form {
 	return form
 }
 
-func New(p kadmin.Publisher, topic *kadmin.Topic) *Model {
+func New(
+	p kadmin.Publisher,
+	topic *kadmin.Topic,
+	keyFormat config.Format,
+	valueFormat config.Format,
+) *Model {
 	return &Model{
-		topic:      topic,
-		publisher:  p,
-		notifier:   notifier.New(),
-		formValues: &formValues{},
+		topic:       topic,
+		publisher:   p,
+		notifier:    notifier.New(),
+		formValues:  &formValues{},
+		keyFormat:   keyFormat,
+		valueFormat: valueFormat,
 	}
 }
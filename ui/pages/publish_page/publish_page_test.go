@@ -1,8 +1,10 @@
 package publish_page
 
 import (
+	"context"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
 	"ktea/tests/keys"
@@ -23,6 +25,10 @@ func (m *MockPublisher) PublishRecord(p *kadmin.ProducerRecord) kadmin.Publicati
 	return kadmin.PublicationStartedMsg{}
 }
 
+func (m *MockPublisher) PublishRecords(ctx context.Context, records []kadmin.ProducerRecord) kadmin.BulkPublicationStartedMsg {
+	return kadmin.BulkPublicationStartedMsg{}
+}
+
 func TestParseHeaders(t *testing.T) {
 	t.Run("header format is key=value", func(t *testing.T) {
 		fv := formValues{
@@ -31,9 +37,9 @@ func TestParseHeaders(t *testing.T) {
 
 		headers := fv.parsedHeaders()
 
-		assert.Equal(t, map[string]string{
-			"key1": "value1",
-			"key2": "value2",
+		assert.Equal(t, []kadmin.Header{
+			{Key: "key1", Value: "value1"},
+			{Key: "key2", Value: "value2"},
 		}, headers)
 	})
 
@@ -44,7 +50,7 @@ func TestParseHeaders(t *testing.T) {
 
 		headers := formValues.parsedHeaders()
 
-		assert.Equal(t, map[string]string{}, headers)
+		assert.Equal(t, []kadmin.Header{}, headers)
 	})
 }
 
@@ -55,7 +61,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 1,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		cmd := m.Update(keys.Key(tea.KeyEsc))
 
@@ -74,7 +80,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 10,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		m.View(&kontext.ProgramKtx{
 			WindowWidth:  100,
@@ -104,20 +110,109 @@ func TestPublish(t *testing.T) {
 
 		keys.Submit(m)
 
-		assert.Equal(t, "key", producerRecord.Key)
+		assert.Equal(t, []byte("key"), producerRecord.Key)
 		assert.Equal(t, "topic1", producerRecord.Topic)
 		assert.Equal(t, 2, *producerRecord.Partition)
-		assert.Equal(t, "payload", producerRecord.Value)
+		assert.Equal(t, []byte("payload"), producerRecord.Value)
 		assert.Equal(
 			t,
-			map[string]string{
-				"id":   "123",
-				"user": "456",
+			[]kadmin.Header{
+				{Key: "id", Value: "123"},
+				{Key: "user", Value: "456"},
 			},
 			producerRecord.Headers,
 		)
 	})
 
+	t.Run("publish in the formats configured for the topic", func(t *testing.T) {
+		var producerRecord *kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+				producerRecord = p
+				return kadmin.PublicationStartedMsg{}
+			},
+		}, &kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, config.LongFormat, config.HexFormat)
+
+		render := m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, ui.TestRenderer)
+
+		assert.Contains(t, render, "Key (long)")
+		assert.Contains(t, render, "Payload (hex)")
+
+		// Key
+		keys.UpdateKeys(m, "1")
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// Partition
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// headers
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		keys.NextGroup(m, cmd)
+
+		// payload
+		keys.UpdateKeys(m, "cafe")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		keys.NextGroup(m, cmd)
+
+		keys.Submit(m)
+
+		assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}, producerRecord.Key)
+		assert.Equal(t, []byte{0xca, 0xfe}, producerRecord.Value)
+	})
+
+	t.Run("publish a null key when the key is left empty", func(t *testing.T) {
+		var producerRecord *kadmin.ProducerRecord
+		m := New(&MockPublisher{
+			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
+				producerRecord = p
+				return kadmin.PublicationStartedMsg{}
+			},
+		}, &kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 10,
+			Replicas:   1,
+			Isr:        1,
+		}, config.LongFormat, config.DefaultFormat)
+
+		m.View(&kontext.ProgramKtx{
+			WindowWidth:  100,
+			WindowHeight: 100,
+		}, ui.TestRenderer)
+
+		// Key
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// Partition
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		// headers
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		keys.NextGroup(m, cmd)
+
+		// payload
+		keys.UpdateKeys(m, "payload")
+		cmd = m.Update(keys.Key(tea.KeyEnter))
+		keys.NextGroup(m, cmd)
+
+		keys.Submit(m)
+
+		assert.NotNil(t, producerRecord)
+		assert.Nil(t, producerRecord.Key)
+		assert.Equal(t, []byte("payload"), producerRecord.Value)
+	})
+
 	t.Run("reset form after successful publication", func(t *testing.T) {
 		m := New(&MockPublisher{
 			PublishRecordFunc: func(p *kadmin.ProducerRecord) kadmin.PublicationStartedMsg {
@@ -128,7 +223,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 10,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		m.View(&kontext.ProgramKtx{
 			WindowWidth:  100,
@@ -179,7 +274,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 10,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		m.View(&kontext.ProgramKtx{
 			WindowWidth:  100,
@@ -206,10 +301,10 @@ func TestPublish(t *testing.T) {
 
 		keys.Submit(m)
 
-		assert.Equal(t, "key", producerRecord.Key)
+		assert.Equal(t, []byte("key"), producerRecord.Key)
 		assert.Equal(t, "topic1", producerRecord.Topic)
 		assert.Nil(t, producerRecord.Partition)
-		assert.Equal(t, "payload", producerRecord.Value)
+		assert.Equal(t, []byte("payload"), producerRecord.Value)
 	})
 
 	t.Run("upon successful publication", func(t *testing.T) {
@@ -222,7 +317,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 10,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		cmds := m.Update(kadmin.PublicationSucceeded{})
 		msgs := executeBatchCmd(cmds)
@@ -252,7 +347,7 @@ func TestPublish(t *testing.T) {
 			Partitions: 10,
 			Replicas:   1,
 			Isr:        1,
-		})
+		}, config.DefaultFormat, config.DefaultFormat)
 
 		m.View(&kontext.ProgramKtx{
 			WindowWidth:  100,
@@ -291,13 +386,36 @@ func TestPublish(t *testing.T) {
 
 	t.Run("Validate", func(t *testing.T) {
 
+		t.Run("When key does not match its format", func(t *testing.T) {
+			m := New(&MockPublisher{}, &kadmin.Topic{
+				Name:       "topic1",
+				Partitions: 1,
+				Replicas:   1,
+				Isr:        1,
+			}, config.LongFormat, config.DefaultFormat)
+
+			m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, ui.TestRenderer)
+			// Key
+			keys.UpdateKeys(m, "one")
+			m.Update(keys.Key(tea.KeyEnter))
+
+			render := m.View(&kontext.ProgramKtx{
+				WindowWidth:  100,
+				WindowHeight: 100,
+			}, ui.TestRenderer)
+			assert.Contains(t, render, "'one' is not a long")
+		})
+
 		t.Run("When partition is not a number", func(t *testing.T) {
 			m := New(&MockPublisher{}, &kadmin.Topic{
 				Name:       "topic1",
 				Partitions: 1,
 				Replicas:   1,
 				Isr:        1,
-			})
+			}, config.DefaultFormat, config.DefaultFormat)
 
 			m.View(&kontext.ProgramKtx{
 				WindowWidth:  100,
@@ -324,7 +442,7 @@ func TestPublish(t *testing.T) {
 				Partitions: 1,
 				Replicas:   1,
 				Isr:        1,
-			})
+			}, config.DefaultFormat, config.DefaultFormat)
 
 			m.View(&kontext.ProgramKtx{
 				WindowWidth:  100,
@@ -351,7 +469,7 @@ func TestPublish(t *testing.T) {
 				Partitions: 1,
 				Replicas:   1,
 				Isr:        1,
-			})
+			}, config.DefaultFormat, config.DefaultFormat)
 
 			m.View(&kontext.ProgramKtx{
 				WindowWidth:  100,
@@ -380,7 +498,7 @@ func TestPublish(t *testing.T) {
 				Partitions: 5,
 				Replicas:   1,
 				Isr:        1,
-			})
+			}, config.DefaultFormat, config.DefaultFormat)
 
 			m.View(&kontext.ProgramKtx{
 				WindowWidth:  100,
@@ -0,0 +1,416 @@
+package reassign_partitions_page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is the time between refreshes while reassignments are in progress.
+const pollInterval = 2 * time.Second
+
+// changedMarker prefixes the proposed replicas that differ from the current ones.
+const changedMarker = "* "
+
+type Model struct {
+	table         table.Model
+	rows          []table.Row
+	notifier      *cmdbar.NotifierCmdBar
+	input         *cmdbar.InputCmdBar
+	describer     kadmin.TopicDescriber
+	brokerLister  kadmin.BrokerLister
+	reassigner    kadmin.PartitionReassigner
+	topic         *kadmin.Topic
+	partitions    []kadmin.PartitionDetails
+	brokers       []kadmin.Broker
+	proposal      map[int32][]int32
+	reassignments map[int32]kadmin.PartitionReassignment
+	// pollPending is true while a poll of the reassignments is scheduled
+	pollPending bool
+}
+
+// ReplicasProposedMsg replaces the proposed replicas of a partition.
+type ReplicasProposedMsg struct {
+	Partition int32
+	Replicas  []int32
+}
+
+// SpreadProposedMsg replaces all proposed replicas with an even spread over the brokers.
+type SpreadProposedMsg struct {
+	Proposal map[int32][]int32
+}
+
+type pollReassignmentsMsg struct{}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{
+		m.notifier.View(ktx, renderer),
+		m.input.View(ktx, renderer),
+	}
+
+	if m.partitions != nil && m.brokers != nil {
+		views = append(views, renderer.Render(m.summaryView()))
+	}
+
+	width := float64(ktx.WindowWidth - 11)
+	m.table.SetHeight(ktx.AvailableHeight - 2)
+	m.table.SetWidth(ktx.WindowWidth - 2)
+	m.table.SetColumns([]table.Column{
+		{"Partition", int(width * 0.12)},
+		{"Leader", int(width * 0.08)},
+		{"Current Replicas", int(width * 0.2)},
+		{"Proposed Replicas", int(width * 0.2)},
+		{"Reassignment", int(width * 0.4)},
+	})
+	m.table.SetRows(m.rows)
+	if m.input.IsFocussed() {
+		views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Blur))
+	} else {
+		views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Focus))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) summaryView() string {
+	var ids []string
+	for _, b := range m.brokers {
+		ids = append(ids, strconv.Itoa(int(b.ID)))
+	}
+	summary := fmt.Sprintf(" Brokers: %s", strings.Join(ids, ", "))
+	if changed := len(m.changedPartitions()); changed > 0 {
+		summary += styles.FG(styles.ColorYellow).Render(fmt.Sprintf(", %d partitions to reassign", changed))
+	}
+	if len(m.reassignments) > 0 {
+		summary += styles.FG(styles.ColorOrange).Render(fmt.Sprintf(", %d reassignments in progress", len(m.reassignments)))
+	}
+	return summary
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	if _, isKeyMsg := msg.(tea.KeyMsg); isKeyMsg && m.input.IsFocussed() {
+		_, _, cmd := m.input.Update(msg)
+		return cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return ui.PublishMsg(nav.LoadTopicDetailsPageMsg{Topic: m.topic})
+		case "f5":
+			return tea.Batch(m.describe, m.listReassignments)
+		case "e":
+			m.editSelectedPartition()
+		case "s":
+			m.editReplicationFactor()
+		case "ctrl+r":
+			m.resetProposal()
+		case "ctrl+s":
+			if len(m.changedPartitions()) > 0 {
+				return m.reassign
+			}
+		}
+	case kadmin.TopicDescriptionStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.TopicDescribedMsg:
+		m.partitions = msg.Partitions
+		if m.proposal == nil {
+			m.resetProposal()
+		}
+	case kadmin.BrokerListingStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.BrokersListedMsg:
+		m.brokers = msg.Brokers
+	case kadmin.PartitionReassignmentsListingStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.PartitionReassignmentsListedMsg:
+		if len(m.reassignments) > 0 && len(msg.Reassignments) == 0 {
+			// the current replicas changed now the reassignments completed
+			cmds = append(cmds, m.describe)
+		}
+		m.reassignments = msg.Reassignments
+		if len(m.reassignments) > 0 && !m.pollPending {
+			m.pollPending = true
+			cmds = append(cmds, tea.Tick(pollInterval, func(time.Time) tea.Msg {
+				return pollReassignmentsMsg{}
+			}))
+		}
+	case pollReassignmentsMsg:
+		m.pollPending = false
+		cmds = append(cmds, m.listReassignments)
+	case kadmin.PartitionReassignmentStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.PartitionsReassignedMsg:
+		m.proposal = nil
+		cmds = append(cmds, m.describe, m.listReassignments)
+	case ReplicasProposedMsg:
+		// the proposal is dropped once reassigned, until the new replicas are described
+		if m.proposal != nil {
+			m.proposal[msg.Partition] = msg.Replicas
+		}
+	case SpreadProposedMsg:
+		m.proposal = msg.Proposal
+	}
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	m.rows = m.createRows()
+
+	t, cmd := m.table.Update(msg)
+	m.table = t
+	cmds = append(cmds, cmd)
+
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) createRows() []table.Row {
+	var rows []table.Row
+	for _, p := range m.partitions {
+		leader := "none"
+		if p.Leader != kadmin.NoLeader {
+			leader = strconv.Itoa(int(p.Leader))
+		}
+		proposed := brokerIds(m.proposal[p.ID])
+		if !slices.Equal(p.Replicas, m.proposal[p.ID]) {
+			proposed = changedMarker + proposed
+		}
+		reassignment := "-"
+		if r, ok := m.reassignments[p.ID]; ok {
+			reassignment = fmt.Sprintf("adding %s, removing %s", brokerIds(r.AddingReplicas), brokerIds(r.RemovingReplicas))
+		}
+		rows = append(rows, table.Row{
+			strconv.Itoa(int(p.ID)),
+			leader,
+			brokerIds(p.Replicas),
+			proposed,
+			reassignment,
+		})
+	}
+	return rows
+}
+
+func brokerIds(ids []int32) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	var s []string
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(int(id)))
+	}
+	return strings.Join(s, ",")
+}
+
+func (m *Model) selectedPartition() (kadmin.PartitionDetails, bool) {
+	row := m.table.SelectedRow()
+	if row == nil {
+		return kadmin.PartitionDetails{}, false
+	}
+	for _, p := range m.partitions {
+		if strconv.Itoa(int(p.ID)) == row[0] {
+			return p, true
+		}
+	}
+	return kadmin.PartitionDetails{}, false
+}
+
+func (m *Model) editSelectedPartition() {
+	partition, ok := m.selectedPartition()
+	if !ok || m.brokers == nil {
+		return
+	}
+	m.input.Activate(
+		fmt.Sprintf("Replicas of partition %d", partition.ID),
+		"comma separated broker ids, the first one is the preferred leader",
+		brokerIds(m.proposal[partition.ID]),
+		func(value string) (tea.Msg, error) {
+			replicas, err := m.parseReplicas(value)
+			if err != nil {
+				return nil, err
+			}
+			return ReplicasProposedMsg{partition.ID, replicas}, nil
+		},
+	)
+}
+
+func (m *Model) editReplicationFactor() {
+	if m.partitions == nil || m.brokers == nil {
+		return
+	}
+	m.input.Activate(
+		"Replication factor",
+		"spreads the replicas of all partitions evenly over the brokers",
+		strconv.Itoa(len(m.partitions[0].Replicas)),
+		func(value string) (tea.Msg, error) {
+			replicationFactor, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a valid number", value)
+			}
+			current := make(map[int32][]int32)
+			for _, p := range m.partitions {
+				current[p.ID] = p.Replicas
+			}
+			proposal, err := kadmin.SpreadReplicas(current, m.brokerIds(), replicationFactor)
+			if err != nil {
+				return nil, err
+			}
+			return SpreadProposedMsg{proposal}, nil
+		},
+	)
+}
+
+// parseReplicas parses comma separated broker ids, which should all exist and be unique.
+func (m *Model) parseReplicas(value string) ([]int32, error) {
+	var replicas []int32
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		broker, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a valid broker id", id)
+		}
+		if !slices.Contains(m.brokerIds(), int32(broker)) {
+			return nil, fmt.Errorf("broker %d does not exist", broker)
+		}
+		if slices.Contains(replicas, int32(broker)) {
+			return nil, fmt.Errorf("broker %d is listed more than once", broker)
+		}
+		replicas = append(replicas, int32(broker))
+	}
+	if len(replicas) == 0 {
+		return nil, errors.New("at least one replica is required")
+	}
+	return replicas, nil
+}
+
+func (m *Model) brokerIds() []int32 {
+	var ids []int32
+	for _, b := range m.brokers {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func (m *Model) resetProposal() {
+	m.proposal = make(map[int32][]int32)
+	for _, p := range m.partitions {
+		m.proposal[p.ID] = p.Replicas
+	}
+}
+
+func (m *Model) changedPartitions() []int32 {
+	var changed []int32
+	for _, p := range m.partitions {
+		if !slices.Equal(p.Replicas, m.proposal[p.ID]) {
+			changed = append(changed, p.ID)
+		}
+	}
+	return changed
+}
+
+func (m *Model) describe() tea.Msg {
+	return m.describer.DescribeTopic(m.topic.Name)
+}
+
+func (m *Model) listBrokers() tea.Msg {
+	return m.brokerLister.ListBrokers()
+}
+
+func (m *Model) listReassignments() tea.Msg {
+	return m.reassigner.ListPartitionReassignments(m.topic.Name)
+}
+
+func (m *Model) reassign() tea.Msg {
+	return m.reassigner.ReassignPartitions(m.topic.Name, m.proposal)
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	if m.input.IsFocussed() {
+		return m.input.Shortcuts()
+	}
+	return []statusbar.Shortcut{
+		{"Edit Replicas", "e"},
+		{"Spread Replicas", "s"},
+		{"Reassign", "C-s"},
+		{"Reset", "C-r"},
+		{"Refresh", "F5"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Reassign Partitions"
+}
+
+func New(
+	describer kadmin.TopicDescriber,
+	brokerLister kadmin.BrokerLister,
+	reassigner kadmin.PartitionReassigner,
+	topic *kadmin.Topic,
+) (*Model, tea.Cmd) {
+	m := &Model{
+		describer:    describer,
+		brokerLister: brokerLister,
+		reassigner:   reassigner,
+		topic:        topic,
+		input:        cmdbar.NewInputCmdBar(),
+	}
+	m.table = table.New(
+		table.WithFocused(true),
+		table.WithStyles(styles.Table.Styles),
+	)
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescriptionStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Loading Partitions")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescribedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.Idle()
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescriptionErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Error describing topic", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.BrokerListingErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Error listing brokers", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionReassignmentsListingErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Error listing reassignments", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionReassignmentStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithRocketMsg("Reassigning partitions")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionsReassignedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowSuccessMsg("Reassignment started")
+		return true, m.AutoHideCmd()
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.PartitionReassignmentErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Reassignment failed", msg.Err)
+		return true, nil
+	})
+	m.notifier = notifierCmdBar
+
+	return m, tea.Batch(m.describe, m.listBrokers, m.listReassignments)
+}
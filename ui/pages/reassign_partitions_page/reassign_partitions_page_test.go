@@ -0,0 +1,220 @@
+package reassign_partitions_page
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+type MockReassigner struct {
+	assignment map[int32][]int32
+}
+
+type ListPartitionReassignmentsCalledMsg struct{}
+
+func (m *MockReassigner) DescribeTopic(topic string) tea.Msg {
+	return nil
+}
+
+func (m *MockReassigner) ListBrokers() tea.Msg {
+	return nil
+}
+
+func (m *MockReassigner) ReassignPartitions(topic string, assignment map[int32][]int32) tea.Msg {
+	m.assignment = assignment
+	return kadmin.PartitionReassignmentStartedMsg{}
+}
+
+func (m *MockReassigner) ListPartitionReassignments(topic string) tea.Msg {
+	return ListPartitionReassignmentsCalledMsg{}
+}
+
+var topic = &kadmin.Topic{Name: "topic1", Partitions: 2, Replicas: 1}
+
+func newLoadedPage(reassigner *MockReassigner) *Model {
+	m, _ := New(reassigner, reassigner, reassigner, topic)
+	m.Update(kadmin.TopicDescribedMsg{
+		Partitions: []kadmin.PartitionDetails{
+			{ID: 0, Leader: 1, Replicas: []int32{1}, Isr: []int32{1}},
+			{ID: 1, Leader: 2, Replicas: []int32{2}, Isr: []int32{2}},
+		},
+	})
+	m.Update(kadmin.BrokersListedMsg{
+		Brokers: []kadmin.Broker{{ID: 1}, {ID: 2}, {ID: 3}},
+	})
+	m.View(ui.NewTestKontext(), ui.TestRenderer)
+	return m
+}
+
+func TestReassignPartitionsPage(t *testing.T) {
+	t.Run("esc goes back to the topic details page", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadTopicDetailsPageMsg{Topic: topic}, cmd())
+	})
+
+	t.Run("show current replicas and brokers", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "Brokers: 1, 2, 3")
+		assert.NotContains(t, render, "partitions to reassign")
+	})
+
+	t.Run("edit replicas of the selected partition", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		m.Update(keys.Key('e'))
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Replicas of partition 0")
+
+		keys.UpdateKeys(m, ",3")
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "* 1,3")
+		assert.Contains(t, render, "1 partitions to reassign")
+	})
+
+	t.Run("reject unknown brokers", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		m.Update(keys.Key('e'))
+		keys.UpdateKeys(m, ",4")
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+
+		assert.Nil(t, cmd)
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "broker 4 does not exist")
+	})
+
+	t.Run("reject duplicate brokers", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		m.Update(keys.Key('e'))
+		keys.UpdateKeys(m, ",1")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "broker 1 is listed more than once")
+	})
+
+	t.Run("spread replicas raising the replication factor", func(t *testing.T) {
+		reassigner := &MockReassigner{}
+		m := newLoadedPage(reassigner)
+
+		m.Update(keys.Key('s'))
+		m.Update(keys.Key(tea.KeyBackspace))
+		keys.UpdateKeys(m, "3")
+		cmd := m.Update(keys.Key(tea.KeyEnter))
+		m.Update(cmd())
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "2 partitions to reassign")
+
+		cmd = m.Update(keys.Key(tea.KeyCtrlS))
+		assert.Equal(t, kadmin.PartitionReassignmentStartedMsg{}, cmd())
+		assert.Len(t, reassigner.assignment[0], 3)
+		assert.Len(t, reassigner.assignment[1], 3)
+		assert.Equal(t, int32(1), reassigner.assignment[0][0])
+		assert.Equal(t, int32(2), reassigner.assignment[1][0])
+	})
+
+	t.Run("reject replication factor exceeding brokers", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		m.Update(keys.Key('s'))
+		m.Update(keys.Key(tea.KeyBackspace))
+		keys.UpdateKeys(m, "4")
+		m.Update(keys.Key(tea.KeyEnter))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "replication factor 4 exceeds the 3 available brokers")
+	})
+
+	t.Run("nothing to reassign without changes", func(t *testing.T) {
+		reassigner := &MockReassigner{}
+		m := newLoadedPage(reassigner)
+
+		cmd := m.Update(keys.Key(tea.KeyCtrlS))
+
+		for _, msg := range tests.ExecuteBatchCmd(cmd) {
+			assert.NotEqual(t, kadmin.PartitionReassignmentStartedMsg{}, msg)
+		}
+		assert.Nil(t, reassigner.assignment)
+	})
+
+	t.Run("reset proposal", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+		m.Update(SpreadProposedMsg{Proposal: map[int32][]int32{0: {1, 2}, 1: {2, 3}}})
+
+		m.Update(keys.Key(tea.KeyCtrlR))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "partitions to reassign")
+	})
+
+	t.Run("track reassignments in progress", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		cmd := m.Update(kadmin.PartitionReassignmentsListedMsg{
+			Reassignments: map[int32]kadmin.PartitionReassignment{
+				0: {Replicas: []int32{1, 3}, AddingReplicas: []int32{3}},
+			},
+		})
+
+		assert.NotNil(t, cmd)
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "adding 3, removing -")
+		assert.Contains(t, render, "1 reassignments in progress")
+
+		cmd = m.Update(pollReassignmentsMsg{})
+		assert.Contains(t, tests.ExecuteBatchCmd(cmd), ListPartitionReassignmentsCalledMsg{})
+	})
+
+	t.Run("poll reassignments once while a poll is pending", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+		listed := kadmin.PartitionReassignmentsListedMsg{
+			Reassignments: map[int32]kadmin.PartitionReassignment{
+				0: {Replicas: []int32{1, 3}, AddingReplicas: []int32{3}},
+			},
+		}
+
+		m.Update(listed)
+		assert.True(t, m.pollPending)
+		m.Update(listed)
+		assert.True(t, m.pollPending)
+
+		m.Update(pollReassignmentsMsg{})
+
+		assert.False(t, m.pollPending)
+	})
+
+	t.Run("ignore proposed replicas after reassigning", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+		m.Update(kadmin.PartitionsReassignedMsg{})
+
+		assert.NotPanics(t, func() {
+			m.Update(ReplicasProposedMsg{Partition: 0, Replicas: []int32{1, 3}})
+		})
+	})
+
+	t.Run("show reassignment errors", func(t *testing.T) {
+		m := newLoadedPage(&MockReassigner{})
+
+		m.Update(kadmin.PartitionReassignmentErrMsg{Err: errors.New("not the controller")})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Reassignment failed")
+	})
+}
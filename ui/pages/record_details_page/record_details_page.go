@@ -1,6 +1,8 @@
 package record_details_page
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -30,6 +32,30 @@ const (
 	headersFocus focus = false
 )
 
+// payloadView is how the payload is displayed
+type payloadView int
+
+const (
+	prettyView payloadView = iota
+	hexView
+	base64View
+)
+
+func (v payloadView) String() string {
+	switch v {
+	case hexView:
+		return "Hex"
+	case base64View:
+		return "Base64"
+	default:
+		return "Pretty"
+	}
+}
+
+func (v payloadView) next() payloadView {
+	return (v + 1) % 3
+}
+
 type Model struct {
 	notifierCmdbar *cmdbar.NotifierCmdBar
 	record         *kadmin.ConsumerRecord
@@ -40,6 +66,7 @@ type Model struct {
 	headerRows     []table.Row
 	focus          focus
 	payload        string
+	payloadView    payloadView
 	metaInfo       string
 	clipWriter     clipper.Writer
 }
@@ -181,6 +208,8 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			m.focus = !m.focus
 		case "c":
 			cmds = m.handleCopy(cmds)
+		case "v":
+			m.showPayloadView(m.payloadView.next())
 		default:
 			cmds = m.updatedFocussedArea(msg, cmds)
 		}
@@ -189,6 +218,42 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+func (m *Model) showPayloadView(view payloadView) {
+	m.payloadView = view
+	m.payload = formatPayload(m.record, view)
+	m.metaInfo = formatMetaInfo(m.record, view)
+	m.payloadVp.SetContent(m.payload)
+	m.payloadVp.GotoTop()
+}
+
+// formatPayload renders the value as pretty printed JSON when possible, or its raw bytes
+// as a hex dump with offset, hex and ASCII columns or as base64.
+func formatPayload(record *kadmin.ConsumerRecord, view payloadView) string {
+	switch view {
+	case hexView:
+		return hex.Dump(record.RawValue)
+	case base64View:
+		return base64.StdEncoding.EncodeToString(record.RawValue)
+	default:
+		return ui.PrettyPrintJson(record.Value)
+	}
+}
+
+func formatMetaInfo(record *kadmin.ConsumerRecord, view payloadView) string {
+	var key string
+	switch {
+	case record.Key == "" && len(record.RawKey) == 0:
+		key = "<null>"
+	case view == hexView:
+		key = hex.EncodeToString(record.RawKey)
+	case view == base64View:
+		key = base64.StdEncoding.EncodeToString(record.RawKey)
+	default:
+		key = record.Key
+	}
+	return fmt.Sprintf("key: %s\ntimestamp: %s", key, record.Timestamp.Format(time.UnixDate))
+}
+
 func (m *Model) handleCopy(cmds []tea.Cmd) []tea.Cmd {
 	if m.focus == payloadFocus {
 		err := m.clipWriter.Write(ansi.Strip(m.payload))
@@ -230,6 +295,7 @@ func (m *Model) Shortcuts() []statusbar.Shortcut {
 		{"Toggle Headers/Content", "C-h/Arrows"},
 		{"Go Back", "esc"},
 		{"Copy " + whatToCopy, "c"},
+		{"Show " + m.payloadView.next().String(), "v"},
 	}
 }
 
@@ -252,15 +318,6 @@ func New(
 		headerRows = append(headerRows, table.Row{header.Key})
 	}
 
-	payload := ui.PrettyPrintJson(record.Value)
-
-	key := record.Key
-	if key == "" {
-		key = "<null>"
-	}
-
-	metaInfo := fmt.Sprintf("key: %s\ntimestamp: %s", key, record.Timestamp.Format(time.UnixDate))
-
 	notifierCmdBar := cmdbar.NewNotifierCmdBar()
 	cmdbar.WithMsgHandler(notifierCmdBar, func(msg PayloadCopiedMsg, m *notifier.Model) (bool, tea.Cmd) {
 		m.ShowSuccessMsg("Payload copied")
@@ -281,8 +338,9 @@ func New(
 		headerKeyTable: &headersTable,
 		focus:          payloadFocus,
 		headerRows:     headerRows,
-		payload:        payload,
-		metaInfo:       metaInfo,
+		payload:        formatPayload(record, prettyView),
+		payloadView:    prettyView,
+		metaInfo:       formatMetaInfo(record, prettyView),
 		clipWriter:     clipWriter,
 		notifierCmdbar: notifierCmdBar,
 	}
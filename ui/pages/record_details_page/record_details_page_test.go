@@ -78,6 +78,56 @@ func TestRecordDetailsPage(t *testing.T) {
 		assert.Contains(t, render, "No headers present")
 	})
 
+	t.Run("v toggles between pretty, hex and base64 views", func(t *testing.T) {
+		var clippedText string
+		clipMock := clipper.NewMock()
+		clipMock.WriteFunc = func(text string) error {
+			clippedText = text
+			return nil
+		}
+		m := New(&kadmin.ConsumerRecord{
+			Key:      "order-1",
+			Value:    `{"name":"John"}`,
+			RawKey:   []byte("order-1"),
+			RawValue: []byte{0x00, 0x00, 0x00, 0x00, 0x01, 'J', 'o', 'h', 'n'},
+			Offset:   123,
+		}, &kadmin.Topic{
+			Name:       "",
+			Partitions: 0,
+			Replicas:   0,
+			Isr:        0,
+		},
+			clipMock,
+		)
+
+		render := ansi.Strip(m.View(ui.NewTestKontext(), ui.TestRenderer))
+
+		assert.Contains(t, render, `"name": "John"`)
+		assert.Contains(t, render, "key: order-1")
+
+		m.Update(keys.Key('v'))
+		render = ansi.Strip(m.View(ui.NewTestKontext(), ui.TestRenderer))
+
+		assert.Contains(t, render, "00000000  00 00 00 00 01 4a 6f 68  6e")
+		assert.Contains(t, render, "|.....John|")
+		assert.Contains(t, render, "key: 6f726465722d31")
+
+		m.Update(keys.Key('c'))
+
+		assert.Equal(t, "00000000  00 00 00 00 01 4a 6f 68  6e                       |.....John|\n", clippedText)
+
+		m.Update(keys.Key('v'))
+		render = ansi.Strip(m.View(ui.NewTestKontext(), ui.TestRenderer))
+
+		assert.Contains(t, render, "AAAAAAFKb2hu")
+		assert.Contains(t, render, "key: b3JkZXItMQ==")
+
+		m.Update(keys.Key('v'))
+		render = ansi.Strip(m.View(ui.NewTestKontext(), ui.TestRenderer))
+
+		assert.Contains(t, render, `"name": "John"`)
+	})
+
 	t.Run("Copy payload", func(t *testing.T) {
 		var clippedText string
 		clipMock := clipper.NewMock()
@@ -0,0 +1,357 @@
+package reset_offsets_page
+
+import (
+	"errors"
+	"fmt"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"strconv"
+	"time"
+)
+
+const timestampLayout = "2006-01-02 15:04:05"
+
+type state int
+
+const (
+	entering state = iota
+	previewing
+	previewed
+	resetting
+	reset
+)
+
+type Model struct {
+	state      state
+	form       *huh.Form
+	table      table.Model
+	rows       []table.Row
+	notifier   *cmdbar.NotifierCmdBar
+	resetter   kadmin.OffsetResetter
+	group      string
+	scopes     []scope
+	preview    *kadmin.OffsetResetPreview
+	formValues *formValues
+}
+
+// scope is a selection of the partitions of the group to reset.
+type scope struct {
+	label      string
+	partitions map[string][]int32
+}
+
+type formValues struct {
+	scope  int
+	target kadmin.OffsetResetTarget
+	value  string
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{m.notifier.View(ktx, renderer)}
+
+	switch m.state {
+	case entering:
+		views = append(views, renderer.RenderWithStyle(m.form.View(), styles.Form))
+	case previewed, resetting, reset:
+		m.table.SetColumns([]table.Column{
+			{Title: "Topic", Width: int(float64(ktx.WindowWidth-11) * 0.4)},
+			{Title: "Partition", Width: int(float64(ktx.WindowWidth-11) * 0.12)},
+			{Title: "Current Offset", Width: int(float64(ktx.WindowWidth-11) * 0.16)},
+			{Title: "New Offset", Width: int(float64(ktx.WindowWidth-11) * 0.16)},
+			{Title: "Change", Width: int(float64(ktx.WindowWidth-11) * 0.16)},
+		})
+		m.table.SetHeight(ktx.AvailableHeight - 2)
+		m.table.SetRows(m.rows)
+		views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Focus))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return tea.Batch(cmds...)
+	case kadmin.OffsetResetPreviewStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+		return tea.Batch(cmds...)
+	case kadmin.OffsetResetPreviewErrMsg:
+		m.initForm()
+		return tea.Batch(cmds...)
+	case kadmin.OffsetResetPreviewedMsg:
+		m.state = previewed
+		m.preview = &msg.Preview
+		m.rows = m.createRows()
+		return tea.Batch(cmds...)
+	case kadmin.OffsetResetStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+		return tea.Batch(cmds...)
+	case kadmin.OffsetResetErrMsg:
+		m.state = previewed
+		return tea.Batch(cmds...)
+	case kadmin.OffsetsResetMsg:
+		m.state = reset
+		return tea.Batch(cmds...)
+	case tea.KeyMsg:
+		switch m.state {
+		case entering:
+			if msg.String() == "esc" {
+				return ui.PublishMsg(nav.LoadCGroupTopicsPageMsg{GroupName: m.group})
+			}
+		case previewed:
+			switch msg.String() {
+			case "esc":
+				m.initForm()
+				return tea.Batch(cmds...)
+			case "ctrl+s":
+				if m.preview.ActiveMembers > 0 {
+					return tea.Batch(cmds...)
+				}
+				m.state = resetting
+				preview := *m.preview
+				return tea.Batch(append(cmds, func() tea.Msg {
+					return m.resetter.ResetOffsets(preview)
+				})...)
+			}
+			t, cmd := m.table.Update(msg)
+			m.table = t
+			return tea.Batch(append(cmds, cmd)...)
+		case reset:
+			if msg.String() == "esc" {
+				return ui.PublishMsg(nav.LoadCGroupTopicsPageMsg{GroupName: m.group})
+			}
+		}
+	}
+
+	if m.state != entering {
+		return tea.Batch(cmds...)
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+	cmds = append(cmds, cmd)
+
+	if m.form.State == huh.StateCompleted {
+		m.state = previewing
+		details := m.resetDetails()
+		cmds = append(cmds, func() tea.Msg {
+			return m.resetter.PreviewOffsetReset(details)
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// resetDetails assumes the form values have been validated.
+func (m *Model) resetDetails() kadmin.OffsetResetDetails {
+	details := kadmin.OffsetResetDetails{
+		Group:      m.group,
+		Partitions: m.scopes[m.formValues.scope].partitions,
+		Target:     m.formValues.target,
+	}
+	switch m.formValues.target {
+	case kadmin.ResetToTimestamp:
+		timestamp, _ := time.ParseInLocation(timestampLayout, m.formValues.value, time.Local)
+		details.Timestamp = timestamp.UnixMilli()
+	case kadmin.ResetToOffset, kadmin.ResetByShift:
+		details.Offset, _ = strconv.ParseInt(m.formValues.value, 10, 64)
+	}
+	return details
+}
+
+func (m *Model) createRows() []table.Row {
+	var rows []table.Row
+	for _, r := range m.preview.Resets {
+		change := r.NewOffset - r.OldOffset
+		formattedChange := strconv.FormatInt(change, 10)
+		if change > 0 {
+			formattedChange = "+" + formattedChange
+		}
+		rows = append(rows, table.Row{
+			r.Topic,
+			strconv.Itoa(int(r.Partition)),
+			strconv.FormatInt(r.OldOffset, 10),
+			strconv.FormatInt(r.NewOffset, 10),
+			formattedChange,
+		})
+	}
+	return rows
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	switch m.state {
+	case previewing, resetting:
+		return nil
+	case previewed:
+		if m.preview.ActiveMembers > 0 {
+			return []statusbar.Shortcut{
+				{"Edit Reset", "esc"},
+			}
+		}
+		return []statusbar.Shortcut{
+			{"Apply Reset", "C-s"},
+			{"Edit Reset", "esc"},
+		}
+	case reset:
+		return []statusbar.Shortcut{
+			{"Go Back", "esc"},
+		}
+	}
+	return []statusbar.Shortcut{
+		{"Preview", "enter"},
+		{"Next Field", "tab"},
+		{"Prev. Field", "s-tab"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Consumer Groups / " + m.group + " / Reset Offsets"
+}
+
+func (m *Model) initForm() {
+	m.state = entering
+	m.preview = nil
+	m.rows = nil
+
+	var scopeOptions []huh.Option[int]
+	for i, s := range m.scopes {
+		scopeOptions = append(scopeOptions, huh.NewOption(s.label, i))
+	}
+	scopeSelect := huh.NewSelect[int]().
+		Title("Partitions").
+		Options(scopeOptions...).
+		Value(&m.formValues.scope)
+	targetSelect := huh.NewSelect[kadmin.OffsetResetTarget]().
+		Title("Reset to").
+		Options(
+			huh.NewOption("Earliest", kadmin.ResetToEarliest),
+			huh.NewOption("Latest", kadmin.ResetToLatest),
+			huh.NewOption("Timestamp", kadmin.ResetToTimestamp),
+			huh.NewOption("Offset", kadmin.ResetToOffset),
+			huh.NewOption("Shift by", kadmin.ResetByShift),
+		).
+		Value(&m.formValues.target)
+	valueInput := huh.NewInput().
+		Title("Value").
+		DescriptionFunc(func() string {
+			switch m.formValues.target {
+			case kadmin.ResetToTimestamp:
+				return "First record at or after " + timestampLayout
+			case kadmin.ResetToOffset:
+				return "Offset, limited to the offsets of each partition"
+			case kadmin.ResetByShift:
+				return "Number of offsets to move, negative to rewind"
+			}
+			return "Not needed"
+		}, &m.formValues.target).
+		Value(&m.formValues.value).
+		Validate(func(value string) error {
+			switch m.formValues.target {
+			case kadmin.ResetToTimestamp:
+				if _, err := time.ParseInLocation(timestampLayout, value, time.Local); err != nil {
+					return errors.New("timestamp must be formatted as " + timestampLayout)
+				}
+			case kadmin.ResetToOffset, kadmin.ResetByShift:
+				if value == "" {
+					return errors.New("value cannot be empty")
+				}
+				n, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return errors.New("'" + value + "' is not a valid number")
+				}
+				if m.formValues.target == kadmin.ResetToOffset && n < 0 {
+					return errors.New("offset cannot be negative")
+				}
+			}
+			return nil
+		})
+
+	form := huh.NewForm(huh.NewGroup(scopeSelect, targetSelect, valueInput))
+	form.QuitAfterSubmit = false
+	form.Init()
+	m.form = form
+}
+
+func New(
+	resetter kadmin.OffsetResetter,
+	group string,
+	topic string,
+	partitions []int32,
+) *Model {
+	m := &Model{
+		resetter:   resetter,
+		group:      group,
+		formValues: &formValues{},
+		table: table.New(
+			table.WithFocused(true),
+			table.WithStyles(styles.Table.Styles),
+		),
+	}
+	m.scopes = []scope{{label: "All topics"}}
+	if topic != "" {
+		m.scopes = append(m.scopes, scope{
+			label:      "All partitions of " + topic,
+			partitions: map[string][]int32{topic: nil},
+		})
+		for _, p := range partitions {
+			m.scopes = append(m.scopes, scope{
+				label:      fmt.Sprintf("Partition %d of %s", p, topic),
+				partitions: map[string][]int32{topic: {p}},
+			})
+		}
+	}
+	m.initForm()
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetResetPreviewStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Previewing offset reset")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetResetPreviewErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to preview offset reset", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetResetPreviewedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		if msg.Preview.ActiveMembers > 0 {
+			m.ShowErrorMsg("Unable to reset offsets", fmt.Errorf(
+				"%s has %d active members, stop its consumers before resetting offsets",
+				msg.Preview.Group,
+				msg.Preview.ActiveMembers,
+			))
+			return true, nil
+		}
+		m.ShowSuccessMsg("Dry run, review the new offsets before applying them")
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetResetStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Resetting offsets")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetResetErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to reset offsets", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.OffsetsResetMsg, n *notifier.Model) (bool, tea.Cmd) {
+		n.ShowSuccessMsg("Offsets of " + m.group + " reset!")
+		return true, nil
+	})
+	m.notifier = notifierCmdBar
+
+	return m
+}
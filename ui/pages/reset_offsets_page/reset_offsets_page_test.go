@@ -0,0 +1,224 @@
+package reset_offsets_page
+
+import (
+	"errors"
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+type MockOffsetResetter struct {
+	details *kadmin.OffsetResetDetails
+	preview *kadmin.OffsetResetPreview
+}
+
+func (m *MockOffsetResetter) PreviewOffsetReset(details kadmin.OffsetResetDetails) tea.Msg {
+	m.details = &details
+	return nil
+}
+
+func (m *MockOffsetResetter) ResetOffsets(preview kadmin.OffsetResetPreview) tea.Msg {
+	m.preview = &preview
+	return nil
+}
+
+// advance feeds the messages of cmd back into the page, skipping cursor blinks.
+func advance(m *Model, cmd tea.Cmd) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, msg := range tests.ExecuteBatchCmd(cmd) {
+		if _, ok := msg.(cursor.BlinkMsg); ok {
+			continue
+		}
+		cmds = append(cmds, m.Update(msg))
+	}
+	return tea.Batch(cmds...)
+}
+
+// fill selects the scope and target by moving down the given number of options and enters the value.
+func fill(m *Model, scope int, target int, value string) tea.Cmd {
+	for i := 0; i < scope; i++ {
+		m.Update(keys.Key(tea.KeyDown))
+	}
+	advance(m, m.Update(keys.Key(tea.KeyEnter)))
+	for i := 0; i < target; i++ {
+		m.Update(keys.Key(tea.KeyDown))
+	}
+	advance(m, m.Update(keys.Key(tea.KeyEnter)))
+	keys.UpdateKeys(m, value)
+	// next group and submit
+	cmd := advance(m, m.Update(keys.Key(tea.KeyEnter)))
+	return advance(m, cmd)
+}
+
+func previewedMsg(activeMembers int) kadmin.OffsetResetPreviewedMsg {
+	return kadmin.OffsetResetPreviewedMsg{
+		Preview: kadmin.OffsetResetPreview{
+			Group: "group1",
+			Resets: []kadmin.OffsetReset{
+				{Topic: "topic1", Partition: 0, OldOffset: 100, NewOffset: 0},
+				{Topic: "topic1", Partition: 1, OldOffset: 100, NewOffset: 150},
+			},
+			ActiveMembers: activeMembers,
+		},
+	}
+}
+
+func TestResetOffsetsPage(t *testing.T) {
+	t.Run("esc goes back to the consumer group", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadCGroupTopicsPageMsg{GroupName: "group1"}, cmd())
+	})
+
+	t.Run("offers all topics, the topic and its partitions", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "All topics")
+		assert.Contains(t, render, "All partitions of topic1")
+		assert.Contains(t, render, "Partition 0 of topic1")
+		assert.Contains(t, render, "Partition 1 of topic1")
+	})
+
+	t.Run("preview reset of all topics to earliest", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+
+		assert.Equal(t, &kadmin.OffsetResetDetails{
+			Group:  "group1",
+			Target: kadmin.ResetToEarliest,
+		}, resetter.details)
+	})
+
+	t.Run("preview reset of a partition to an offset", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		tests.ExecuteBatchCmd(fill(m, 3, 3, "42"))
+
+		assert.Equal(t, &kadmin.OffsetResetDetails{
+			Group:      "group1",
+			Partitions: map[string][]int32{"topic1": {1}},
+			Target:     kadmin.ResetToOffset,
+			Offset:     42,
+		}, resetter.details)
+	})
+
+	t.Run("shift requires a number", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		tests.ExecuteBatchCmd(fill(m, 1, 4, "abc"))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "'abc' is not a valid number")
+		assert.Nil(t, resetter.details)
+	})
+
+	t.Run("timestamp must match the layout", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		tests.ExecuteBatchCmd(fill(m, 1, 2, "yesterday"))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "timestamp must be formatted as 2006-01-02 15:04:05")
+		assert.Nil(t, resetter.details)
+	})
+
+	t.Run("show current and new offsets", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+
+		m.Update(previewedMsg(0))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Dry run, review the new offsets before applying them")
+		assert.Regexp(t, `topic1\s+0\s+100\s+0\s+-100`, render)
+		assert.Regexp(t, `topic1\s+1\s+100\s+150\s+\+50`, render)
+	})
+
+	t.Run("apply previewed reset", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+		m.Update(previewedMsg(0))
+
+		cmd := m.Update(keys.Key(tea.KeyCtrlS))
+		tests.ExecuteBatchCmd(cmd)
+
+		preview := previewedMsg(0).Preview
+		assert.Equal(t, &preview, resetter.preview)
+	})
+
+	t.Run("refuse reset while the group has active members", func(t *testing.T) {
+		resetter := &MockOffsetResetter{}
+		m := New(resetter, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+		m.Update(previewedMsg(2))
+
+		cmd := m.Update(keys.Key(tea.KeyCtrlS))
+		tests.ExecuteBatchCmd(cmd)
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "group1 has 2 active members")
+		assert.Nil(t, resetter.preview)
+	})
+
+	t.Run("esc on preview edits the reset", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+		m.Update(previewedMsg(0))
+
+		m.Update(keys.Key(tea.KeyEsc))
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Reset to")
+	})
+
+	t.Run("show error when preview fails", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+
+		m.Update(kadmin.OffsetResetPreviewErrMsg{Err: errors.New("group not found")})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Unable to preview offset reset")
+		assert.Contains(t, render, "Reset to")
+	})
+
+	t.Run("go back to the consumer group after reset", func(t *testing.T) {
+		m := New(&MockOffsetResetter{}, "group1", "topic1", []int32{0, 1})
+		m.View(ui.NewTestKontext(), ui.TestRenderer)
+		tests.ExecuteBatchCmd(fill(m, 0, 0, ""))
+		m.Update(previewedMsg(0))
+		m.Update(keys.Key(tea.KeyCtrlS))
+
+		m.Update(kadmin.OffsetsResetMsg{})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Offsets of group1 reset!")
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+		assert.Equal(t, nav.LoadCGroupTopicsPageMsg{GroupName: "group1"}, cmd())
+	})
+}
@@ -0,0 +1,158 @@
+package topic_cgroups_page
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"strconv"
+)
+
+type Model struct {
+	table    table.Model
+	rows     []table.Row
+	notifier *cmdbar.NotifierCmdBar
+	lister   kadmin.TopicCGroupLister
+	topic    *kadmin.Topic
+	groups   []kadmin.TopicConsumerGroup
+	listed   bool
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	views := []string{m.notifier.View(ktx, renderer)}
+
+	if m.listed {
+		views = append(views, renderer.Render(m.summaryView()))
+	}
+
+	width := float64(ktx.WindowWidth - 9)
+	m.table.SetHeight(ktx.AvailableHeight - 2)
+	m.table.SetWidth(ktx.WindowWidth - 2)
+	m.table.SetColumns([]table.Column{
+		{"Consumer Group", int(width * 0.5)},
+		{"State", int(width * 0.2)},
+		{"Members", int(width * 0.1)},
+		{"Lag", int(width * 0.2)},
+	})
+	m.table.SetRows(m.rows)
+	views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Focus))
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) summaryView() string {
+	if len(m.groups) == 0 {
+		return " No consumer group committed offsets for " + m.topic.Name
+	}
+	var active int
+	for _, g := range m.groups {
+		if g.Members > 0 {
+			active++
+		}
+	}
+	return fmt.Sprintf(
+		" %d consumer groups committed offsets for %s, %d with active members",
+		len(m.groups),
+		m.topic.Name,
+		active,
+	)
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
+		case "f5":
+			m.groups = nil
+			m.rows = nil
+			m.listed = false
+			return m.list
+		}
+	case kadmin.TopicCGroupListingStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.TopicCGroupsListedMsg:
+		m.groups = msg.CGroups
+		m.listed = true
+		m.rows = m.createRows()
+	}
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	t, cmd := m.table.Update(msg)
+	m.table = t
+	cmds = append(cmds, cmd)
+
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) createRows() []table.Row {
+	var rows []table.Row
+	for _, g := range m.groups {
+		lag := "-"
+		if g.Lag != kadmin.UnknownLag {
+			lag = strconv.FormatInt(g.Lag, 10)
+		}
+		rows = append(rows, table.Row{
+			g.Name,
+			g.State,
+			strconv.Itoa(g.Members),
+			lag,
+		})
+	}
+	return rows
+}
+
+func (m *Model) list() tea.Msg {
+	return m.lister.ListTopicCGroups(m.topic.Name)
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	return []statusbar.Shortcut{
+		{"Refresh", "F5"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name + " / Consumer Groups"
+}
+
+func New(lister kadmin.TopicCGroupLister, topic *kadmin.Topic) (*Model, tea.Cmd) {
+	m := &Model{
+		lister: lister,
+		topic:  topic,
+		table: table.New(
+			table.WithFocused(true),
+			table.WithStyles(styles.Table.Styles),
+		),
+	}
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicCGroupListingStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Loading Consumer Groups")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicCGroupsListedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.Idle()
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicCGroupListingErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to list consumer groups", msg.Err)
+		return true, nil
+	})
+	m.notifier = notifierCmdBar
+
+	return m, m.list
+}
@@ -0,0 +1,82 @@
+package topic_cgroups_page
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+type ListTopicCGroupsCalledMsg struct {
+	Topic string
+}
+
+type MockTopicCGroupLister struct{}
+
+func (m *MockTopicCGroupLister) ListTopicCGroups(topic string) tea.Msg {
+	return ListTopicCGroupsCalledMsg{topic}
+}
+
+func TestTopicCGroupsPage(t *testing.T) {
+	topic := &kadmin.Topic{Name: "topic1", Partitions: 3, Replicas: 1}
+
+	t.Run("list consumer groups of the topic on load", func(t *testing.T) {
+		_, cmd := New(&MockTopicCGroupLister{}, topic)
+
+		assert.Equal(t, ListTopicCGroupsCalledMsg{"topic1"}, cmd())
+	})
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m, _ := New(&MockTopicCGroupLister{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+
+	t.Run("F5 refreshes the consumer groups", func(t *testing.T) {
+		m, _ := New(&MockTopicCGroupLister{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyF5))
+
+		assert.Equal(t, ListTopicCGroupsCalledMsg{"topic1"}, cmd())
+	})
+
+	t.Run("show state, members and lag of the groups", func(t *testing.T) {
+		m, _ := New(&MockTopicCGroupLister{}, topic)
+
+		m.Update(kadmin.TopicCGroupsListedMsg{
+			CGroups: []kadmin.TopicConsumerGroup{
+				{Name: "group1", State: "Stable", Members: 2, Lag: 42},
+				{Name: "group2", State: "Empty", Members: 0, Lag: kadmin.UnknownLag},
+			},
+		})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "2 consumer groups committed offsets for topic1, 1 with active members")
+		assert.Regexp(t, `group1\s+Stable\s+2\s+42`, render)
+		assert.Regexp(t, `group2\s+Empty\s+0\s+-`, render)
+	})
+
+	t.Run("show when no group consumes the topic", func(t *testing.T) {
+		m, _ := New(&MockTopicCGroupLister{}, topic)
+
+		m.Update(kadmin.TopicCGroupsListedMsg{})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "No consumer group committed offsets for topic1")
+	})
+
+	t.Run("show error when listing fails", func(t *testing.T) {
+		m, _ := New(&MockTopicCGroupLister{}, topic)
+
+		m.Update(kadmin.TopicCGroupListingErrMsg{Err: errors.New("not authorized")})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Unable to list consumer groups")
+	})
+}
@@ -0,0 +1,429 @@
+package topic_details_page
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ktea/kadmin"
+	"ktea/kontext"
+	"ktea/styles"
+	"ktea/ui"
+	"ktea/ui/components/cmdbar"
+	"ktea/ui/components/notifier"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
+	"strconv"
+	"strings"
+)
+
+// underReplicatedMarker prefixes the partitions with fewer in-sync replicas than replicas.
+const underReplicatedMarker = "⚠ "
+
+// markedMarker prefixes the partitions marked to delete records from.
+const markedMarker = "✓ "
+
+type Model struct {
+	table        table.Model
+	rows         []table.Row
+	notifier     *cmdbar.NotifierCmdBar
+	input        *cmdbar.InputCmdBar
+	deleteCmdBar *cmdbar.DeleteCmdBar[kadmin.RecordDeletionDetails]
+	deleting     bool
+	describer    kadmin.TopicDescriber
+	deleter      kadmin.RecordDeleter
+	topic        *kadmin.Topic
+	partitions   []kadmin.PartitionDetails
+	marked       map[int32]bool
+}
+
+// DeletionRequestedMsg asks for confirmation before deleting the records.
+type DeletionRequestedMsg struct {
+	Details kadmin.RecordDeletionDetails
+}
+
+// DeletionRefusedMsg tells the records cannot be deleted.
+type DeletionRefusedMsg struct {
+	Err error
+}
+
+func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
+	var views []string
+	if m.deleting {
+		views = append(views, m.deleteCmdBar.View(ktx, renderer))
+	} else if m.input.IsFocussed() {
+		views = append(views, m.input.View(ktx, renderer))
+	} else {
+		views = append(views, m.notifier.View(ktx, renderer))
+	}
+
+	if m.partitions != nil {
+		views = append(views, renderer.Render(m.summaryView()))
+	}
+
+	width := float64(ktx.WindowWidth - 19)
+	m.table.SetHeight(ktx.AvailableHeight - 2)
+	m.table.SetWidth(ktx.WindowWidth - 2)
+	m.table.SetColumns([]table.Column{
+		{"Partition", int(width * 0.1)},
+		{"Leader", int(width * 0.08)},
+		{"Replicas", int(width * 0.12)},
+		{"ISR", int(width * 0.12)},
+		{"Offline", int(width * 0.12)},
+		{"Low Watermark", int(width * 0.15)},
+		{"High Watermark", int(width * 0.15)},
+		{"Messages", int(width * 0.16)},
+	})
+	m.table.SetRows(m.rows)
+	if m.deleting || m.input.IsFocussed() {
+		views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Blur))
+	} else {
+		views = append(views, renderer.RenderWithStyle(m.table.View(), styles.Table.Focus))
+	}
+
+	return ui.JoinVertical(lipgloss.Top, views...)
+}
+
+func (m *Model) summaryView() string {
+	var messages int64
+	var underReplicated, offline int
+	for _, p := range m.partitions {
+		messages += p.MessageCount()
+		if p.UnderReplicated() {
+			underReplicated++
+		}
+		if len(p.OfflineReplicas) > 0 {
+			offline++
+		}
+	}
+
+	summary := fmt.Sprintf(" %d partitions, %d messages", len(m.partitions), messages)
+	if underReplicated == 0 && offline == 0 {
+		return summary + styles.FG(styles.ColorGreen).Render(", all replicas in sync")
+	}
+	return summary + styles.FG(styles.ColorRed).Render(fmt.Sprintf(
+		", %d under-replicated, %d with offline replicas",
+		underReplicated,
+		offline,
+	))
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	var cmds []tea.Cmd
+
+	if _, isKeyMsg := msg.(tea.KeyMsg); isKeyMsg {
+		if m.input.IsFocussed() {
+			_, _, cmd := m.input.Update(msg)
+			return cmd
+		}
+		if m.deleting {
+			active, _, cmd := m.deleteCmdBar.Update(msg)
+			// the deletion got confirmed when a cmd is returned
+			m.deleting = active && cmd == nil
+			return cmd
+		}
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return ui.PublishMsg(nav.LoadTopicsPageMsg{})
+		case "r":
+			return ui.PublishMsg(nav.LoadReassignPartitionsPageMsg{Topic: m.topic})
+		case "m":
+			m.toggleMark()
+		case "f2":
+			m.askOffset()
+		case "ctrl+e":
+			if m.partitions != nil {
+				offsets, err := highWatermarks(m.partitions)
+				if err != nil {
+					return ui.PublishMsg(DeletionRefusedMsg{err})
+				}
+				m.confirmDeletion(offsets)
+			}
+		case "f5":
+			m.partitions = nil
+			m.rows = nil
+			return m.describe
+		}
+	case kadmin.TopicDescriptionStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.TopicDescribedMsg:
+		m.partitions = msg.Partitions
+	case DeletionRequestedMsg:
+		m.confirmDeletion(msg.Details.Offsets)
+	case kadmin.RecordDeletionStartedMsg:
+		cmds = append(cmds, msg.AwaitCompletion)
+	case kadmin.RecordsDeletedMsg:
+		m.marked = make(map[int32]bool)
+		cmds = append(cmds, m.describe)
+	}
+	m.rows = m.createRows()
+
+	_, _, cmd := m.notifier.Update(msg)
+	cmds = append(cmds, cmd)
+
+	t, cmd := m.table.Update(msg)
+	m.table = t
+	cmds = append(cmds, cmd)
+
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) createRows() []table.Row {
+	var rows []table.Row
+	for _, p := range m.partitions {
+		partition := strconv.Itoa(int(p.ID))
+		if p.UnderReplicated() {
+			partition = underReplicatedMarker + partition
+		}
+		if m.marked[p.ID] {
+			partition = markedMarker + partition
+		}
+		leader := "none"
+		watermarks := []string{"-", "-", "-"}
+		if p.Leader != kadmin.NoLeader {
+			leader = strconv.Itoa(int(p.Leader))
+			watermarks = []string{
+				strconv.FormatInt(p.LowWatermark, 10),
+				strconv.FormatInt(p.HighWatermark, 10),
+				strconv.FormatInt(p.MessageCount(), 10),
+			}
+		}
+		rows = append(rows, append(table.Row{
+			partition,
+			leader,
+			brokerIds(p.Replicas),
+			brokerIds(p.Isr),
+			brokerIds(p.OfflineReplicas),
+		}, watermarks...))
+	}
+	return rows
+}
+
+func brokerIds(ids []int32) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	var s []string
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(int(id)))
+	}
+	return strings.Join(s, ",")
+}
+
+func (m *Model) selectedPartition() (kadmin.PartitionDetails, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.partitions) {
+		return kadmin.PartitionDetails{}, false
+	}
+	return m.partitions[cursor], true
+}
+
+func (m *Model) toggleMark() {
+	if p, ok := m.selectedPartition(); ok {
+		m.marked[p.ID] = !m.marked[p.ID]
+	}
+}
+
+// chosenPartitions returns the marked partitions, or the selected one when none are marked.
+func (m *Model) chosenPartitions() []kadmin.PartitionDetails {
+	var chosen []kadmin.PartitionDetails
+	for _, p := range m.partitions {
+		if m.marked[p.ID] {
+			chosen = append(chosen, p)
+		}
+	}
+	if len(chosen) == 0 {
+		if p, ok := m.selectedPartition(); ok {
+			chosen = append(chosen, p)
+		}
+	}
+	return chosen
+}
+
+func (m *Model) askOffset() {
+	chosen := m.chosenPartitions()
+	if len(chosen) == 0 {
+		return
+	}
+	var ids []int32
+	for _, p := range chosen {
+		ids = append(ids, p.ID)
+	}
+	m.input.Activate(
+		"Delete records of partitions "+brokerIds(ids)+" before offset",
+		"leave empty to delete all records",
+		"",
+		func(value string) (tea.Msg, error) {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				offsets, err := highWatermarks(chosen)
+				if err != nil {
+					return nil, err
+				}
+				return DeletionRequestedMsg{m.deletionDetails(offsets)}, nil
+			}
+			offset, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("'%s' is not a valid offset", value)
+			}
+			offsets := make(map[int32]int64)
+			for _, p := range chosen {
+				if !p.HasWatermarks() {
+					return nil, errNoLeader(p)
+				}
+				if offset < p.LowWatermark || offset > p.HighWatermark {
+					return nil, fmt.Errorf(
+						"offset of partition %d must be between %d and %d",
+						p.ID,
+						p.LowWatermark,
+						p.HighWatermark,
+					)
+				}
+				offsets[p.ID] = offset
+			}
+			return DeletionRequestedMsg{m.deletionDetails(offsets)}, nil
+		},
+	)
+}
+
+// highWatermarks returns the offsets to delete all records of the partitions before,
+// refusing partitions without leader as their watermarks are unknown.
+func highWatermarks(partitions []kadmin.PartitionDetails) (map[int32]int64, error) {
+	offsets := make(map[int32]int64)
+	for _, p := range partitions {
+		if !p.HasWatermarks() {
+			return nil, errNoLeader(p)
+		}
+		offsets[p.ID] = p.HighWatermark
+	}
+	return offsets, nil
+}
+
+func errNoLeader(p kadmin.PartitionDetails) error {
+	return fmt.Errorf("unable to delete records of partition %d: partition has no leader", p.ID)
+}
+
+func (m *Model) deletionDetails(offsets map[int32]int64) kadmin.RecordDeletionDetails {
+	return kadmin.RecordDeletionDetails{Topic: m.topic.Name, Offsets: offsets}
+}
+
+func (m *Model) confirmDeletion(offsets map[int32]int64) {
+	m.deleteCmdBar.Activate(m.deletionDetails(offsets))
+	m.deleting = true
+}
+
+// deletionMsg describes the records that will be deleted.
+func (m *Model) deletionMsg(rdd kadmin.RecordDeletionDetails) string {
+	var ids []int32
+	var count int64
+	toHighWatermarks := true
+	for _, p := range m.partitions {
+		offset, ok := rdd.Offsets[p.ID]
+		if !ok {
+			continue
+		}
+		ids = append(ids, p.ID)
+		count += max(offset-p.LowWatermark, 0)
+		toHighWatermarks = toHighWatermarks && offset == p.HighWatermark
+	}
+
+	var message string
+	if toHighWatermarks && len(ids) == len(m.partitions) {
+		message = fmt.Sprintf("All %d records of %s", count, m.topic.Name)
+	} else if toHighWatermarks {
+		message = fmt.Sprintf("All %d records of partitions %s", count, brokerIds(ids))
+	} else {
+		message = fmt.Sprintf(
+			"%d records of partitions %s before offset %d",
+			count,
+			brokerIds(ids),
+			rdd.Offsets[ids[0]],
+		)
+	}
+	return message + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7571F9")).
+		Bold(true).
+		Render(" will be deleted permanently")
+}
+
+func (m *Model) describe() tea.Msg {
+	return m.describer.DescribeTopic(m.topic.Name)
+}
+
+func (m *Model) Shortcuts() []statusbar.Shortcut {
+	if m.deleting {
+		return m.deleteCmdBar.Shortcuts()
+	}
+	if m.input.IsFocussed() {
+		return m.input.Shortcuts()
+	}
+	return []statusbar.Shortcut{
+		{"Mark", "m"},
+		{"Delete Records", "F2"},
+		{"Empty Topic", "C-e"},
+		{"Reassign", "r"},
+		{"Refresh", "F5"},
+		{"Go Back", "esc"},
+	}
+}
+
+func (m *Model) Title() string {
+	return "Topics / " + m.topic.Name
+}
+
+func New(describer kadmin.TopicDescriber, deleter kadmin.RecordDeleter, topic *kadmin.Topic) (*Model, tea.Cmd) {
+	m := &Model{}
+	m.describer = describer
+	m.deleter = deleter
+	m.topic = topic
+	m.marked = make(map[int32]bool)
+	m.input = cmdbar.NewInputCmdBar()
+	m.deleteCmdBar = cmdbar.NewDeleteCmdBar(
+		m.deletionMsg,
+		func(rdd kadmin.RecordDeletionDetails) tea.Cmd {
+			return func() tea.Msg {
+				return m.deleter.DeleteRecords(rdd)
+			}
+		},
+		nil,
+	)
+	m.table = table.New(
+		table.WithFocused(true),
+		table.WithStyles(styles.Table.Styles),
+	)
+
+	notifierCmdBar := cmdbar.NewNotifierCmdBar()
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescriptionStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Loading Partitions")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescribedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.Idle()
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.TopicDescriptionErrorMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Error describing topic", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.RecordDeletionStartedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		return true, m.SpinWithLoadingMsg("Deleting records")
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.RecordsDeletedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowSuccessMsg("Records deleted")
+		return true, m.AutoHideCmd()
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg DeletionRefusedMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to empty topic", msg.Err)
+		return true, nil
+	})
+	cmdbar.WithMsgHandler(notifierCmdBar, func(msg kadmin.RecordDeletionErrMsg, m *notifier.Model) (bool, tea.Cmd) {
+		m.ShowErrorMsg("Unable to delete records", msg.Err)
+		return true, nil
+	})
+	m.notifier = notifierCmdBar
+
+	return m, m.describe
+}
@@ -0,0 +1,311 @@
+package topic_details_page
+
+import (
+	"errors"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"ktea/kadmin"
+	"ktea/tests"
+	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/pages/nav"
+	"testing"
+)
+
+type MockTopicDescriber struct{}
+
+type DescribeTopicCalledMsg struct {
+	Topic string
+}
+
+func (m *MockTopicDescriber) DescribeTopic(topic string) tea.Msg {
+	return DescribeTopicCalledMsg{topic}
+}
+
+type DeleteRecordsCalledMsg struct {
+	Details kadmin.RecordDeletionDetails
+}
+
+func (m *MockTopicDescriber) DeleteRecords(rdd kadmin.RecordDeletionDetails) tea.Msg {
+	return DeleteRecordsCalledMsg{rdd}
+}
+
+func TestTopicDetailsPage(t *testing.T) {
+	topic := &kadmin.Topic{Name: "topic1", Partitions: 2, Replicas: 3}
+
+	t.Run("Describe topic on load", func(t *testing.T) {
+		_, cmd := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		assert.Equal(t, DescribeTopicCalledMsg{"topic1"}, cmd())
+	})
+
+	t.Run("Show partitions", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		m.Update(kadmin.TopicDescribedMsg{
+			Partitions: []kadmin.PartitionDetails{
+				{
+					ID:            0,
+					Leader:        1,
+					Replicas:      []int32{1, 2, 3},
+					Isr:           []int32{1, 2, 3},
+					LowWatermark:  10,
+					HighWatermark: 110,
+				},
+				{
+					ID:            1,
+					Leader:        2,
+					Replicas:      []int32{2, 3, 1},
+					Isr:           []int32{2, 3, 1},
+					LowWatermark:  0,
+					HighWatermark: 50,
+				},
+			},
+		})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "2 partitions, 150 messages, all replicas in sync")
+		assert.Contains(t, render, "1,2,3")
+		assert.Contains(t, render, "110")
+		assert.Contains(t, render, "100")
+		assert.NotContains(t, render, "⚠")
+	})
+
+	t.Run("Highlight under-replicated partitions", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		m.Update(kadmin.TopicDescribedMsg{
+			Partitions: []kadmin.PartitionDetails{
+				{
+					ID:              0,
+					Leader:          1,
+					Replicas:        []int32{1, 2},
+					Isr:             []int32{1},
+					OfflineReplicas: []int32{2},
+					LowWatermark:    0,
+					HighWatermark:   5,
+				},
+				{
+					ID:            1,
+					Leader:        kadmin.NoLeader,
+					Replicas:      []int32{2},
+					LowWatermark:  kadmin.NoLeader,
+					HighWatermark: kadmin.NoLeader,
+				},
+			},
+		})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "2 under-replicated, 1 with offline replicas")
+		assert.Contains(t, render, "⚠ 0")
+		assert.Contains(t, render, "⚠ 1")
+		assert.Contains(t, render, "none")
+	})
+
+	t.Run("Show error when describing fails", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		m.Update(kadmin.TopicDescriptionErrorMsg{Err: errors.New("unknown topic")})
+
+		render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "Error describing topic")
+	})
+
+	t.Run("F5 refreshes", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyF5))
+
+		assert.Equal(t, DescribeTopicCalledMsg{"topic1"}, cmd())
+	})
+
+	t.Run("esc goes back to topics page", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		cmd := m.Update(keys.Key(tea.KeyEsc))
+
+		assert.Equal(t, nav.LoadTopicsPageMsg{}, cmd())
+	})
+	t.Run("r loads the reassign partitions page", func(t *testing.T) {
+		m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+
+		cmd := m.Update(keys.Key('r'))
+
+		assert.Equal(t, nav.LoadReassignPartitionsPageMsg{Topic: topic}, cmd())
+	})
+
+	t.Run("Delete records", func(t *testing.T) {
+		described := kadmin.TopicDescribedMsg{
+			Partitions: []kadmin.PartitionDetails{
+				{ID: 0, Leader: 1, Replicas: []int32{1}, Isr: []int32{1}, LowWatermark: 10, HighWatermark: 110},
+				{ID: 1, Leader: 1, Replicas: []int32{1}, Isr: []int32{1}, LowWatermark: 0, HighWatermark: 50},
+				{ID: 2, Leader: 1, Replicas: []int32{1}, Isr: []int32{1}, LowWatermark: 5, HighWatermark: 30},
+			},
+		}
+
+		t.Run("before an offset of the selected partition", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			m.Update(keys.Key(tea.KeyF2))
+			keys.UpdateKeys(m, "60")
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+			m.Update(cmd())
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "50 records of partitions 0 before offset 60")
+
+			m.Update(keys.Key('d'))
+			cmd = m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Equal(t, DeleteRecordsCalledMsg{kadmin.RecordDeletionDetails{
+				Topic:   "topic1",
+				Offsets: map[int32]int64{0: 60},
+			}}, cmd())
+		})
+
+		t.Run("of the marked partitions up to their high watermarks", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+			m.View(ui.NewTestKontext(), ui.TestRenderer)
+
+			m.Update(keys.Key('m'))
+			m.Update(keys.Key(tea.KeyDown))
+			m.Update(keys.Key(tea.KeyDown))
+			m.Update(keys.Key('m'))
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "✓ 0")
+			assert.Contains(t, render, "✓ 2")
+			assert.NotContains(t, render, "✓ 1")
+
+			m.Update(keys.Key(tea.KeyF2))
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+			m.Update(cmd())
+
+			render = m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "All 125 records of partitions 0,2")
+
+			m.Update(keys.Key('d'))
+			cmd = m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Equal(t, DeleteRecordsCalledMsg{kadmin.RecordDeletionDetails{
+				Topic:   "topic1",
+				Offsets: map[int32]int64{0: 110, 2: 30},
+			}}, cmd())
+		})
+
+		t.Run("offset must be within the watermarks", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			m.Update(keys.Key(tea.KeyF2))
+			keys.UpdateKeys(m, "5")
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Nil(t, cmd)
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "offset of partition 0 must be between 10 and 110")
+		})
+
+		t.Run("empty topic", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			m.Update(keys.Key(tea.KeyCtrlE))
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "All 175 records of topic1")
+
+			m.Update(keys.Key('d'))
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Equal(t, DeleteRecordsCalledMsg{kadmin.RecordDeletionDetails{
+				Topic:   "topic1",
+				Offsets: map[int32]int64{0: 110, 1: 50, 2: 30},
+			}}, cmd())
+		})
+
+		leaderless := kadmin.TopicDescribedMsg{
+			Partitions: []kadmin.PartitionDetails{
+				{ID: 0, Leader: kadmin.NoLeader, Replicas: []int32{1}, LowWatermark: kadmin.NoLeader, HighWatermark: kadmin.NoLeader},
+				{ID: 1, Leader: 1, Replicas: []int32{1}, Isr: []int32{1}, LowWatermark: 0, HighWatermark: 50},
+			},
+		}
+
+		t.Run("refuse deleting before an offset of a partition without leader", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(leaderless)
+
+			m.Update(keys.Key(tea.KeyF2))
+			keys.UpdateKeys(m, "-1")
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Nil(t, cmd)
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "partition has no leader")
+		})
+
+		t.Run("refuse deleting all records of a partition without leader", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(leaderless)
+
+			m.Update(keys.Key(tea.KeyF2))
+			cmd := m.Update(keys.Key(tea.KeyEnter))
+
+			assert.Nil(t, cmd)
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "partition has no leader")
+		})
+
+		t.Run("refuse emptying a topic with a partition without leader", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(leaderless)
+
+			cmd := m.Update(keys.Key(tea.KeyCtrlE))
+			for _, msg := range tests.ExecuteBatchCmd(cmd) {
+				m.Update(msg)
+			}
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "Unable to empty topic")
+			assert.NotContains(t, render, "will be deleted permanently")
+		})
+
+		t.Run("cancel deletion", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			m.Update(keys.Key(tea.KeyCtrlE))
+			m.Update(keys.Key(tea.KeyEsc))
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.NotContains(t, render, "will be deleted permanently")
+		})
+
+		t.Run("refresh after deletion", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			cmd := m.Update(kadmin.RecordsDeletedMsg{})
+
+			msgs := tests.ExecuteBatchCmd(cmd)
+			assert.Contains(t, msgs, DescribeTopicCalledMsg{"topic1"})
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "Records deleted")
+		})
+
+		t.Run("show error when deleting fails", func(t *testing.T) {
+			m, _ := New(&MockTopicDescriber{}, &MockTopicDescriber{}, topic)
+			m.Update(described)
+
+			m.Update(kadmin.RecordDeletionErrMsg{Err: errors.New("policy violation")})
+
+			render := m.View(ui.NewTestKontext(), ui.TestRenderer)
+			assert.Contains(t, render, "Unable to delete records")
+		})
+	})
+}
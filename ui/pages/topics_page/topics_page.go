@@ -2,6 +2,7 @@ package topics_page
 
 import (
 	"context"
+	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,20 +19,27 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type state int
 
 type Model struct {
-	topics        []kadmin.Topic
-	table         table.Model
-	shortcuts     []statusbar.Shortcut
-	cmdBar        *cmdbar.TableCmdsBar[string]
-	rows          []table.Row
-	moveCursor    func()
-	lister        kadmin.TopicLister
-	ctx           context.Context
-	tableFocussed bool
+	topics       []kadmin.Topic
+	table        table.Model
+	shortcuts    []statusbar.Shortcut
+	cmdBar       *cmdbar.TableCmdsBar[string]
+	rows         []table.Row
+	moveCursor   func()
+	lister       kadmin.TopicLister
+	statsLister  kadmin.TopicStatsLister
+	statsListing *kadmin.TopicStatsListingStartedMsg
+	// statsListingId identifies the current stats listing, messages of earlier listings are ignored
+	statsListingId int
+	cancelStats    context.CancelFunc
+	showStats      bool
+	ctx            context.Context
+	tableFocussed  bool
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -41,12 +49,25 @@ func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
 
 	m.table.SetHeight(ktx.AvailableHeight - 2)
 	m.table.SetWidth(ktx.WindowWidth - 2)
-	m.table.SetColumns([]table.Column{
-		{"Name", int(float64(ktx.WindowWidth-9) * 0.7)},
-		{"Partitions", int(float64(ktx.WindowWidth-9) * 0.1)},
-		{"Replicas", int(float64(ktx.WindowWidth-9) * 0.1)},
-		{"In Sync Replicas", int(float64(ktx.WindowWidth-9) * 0.1)},
-	})
+	if m.showStats {
+		width := float64(ktx.WindowWidth - 15)
+		m.table.SetColumns([]table.Column{
+			{"Name", int(width * 0.3)},
+			{"Partitions", int(width * 0.08)},
+			{"Replicas", int(width * 0.08)},
+			{"In Sync Replicas", int(width * 0.1)},
+			{"Messages", int(width * 0.12)},
+			{"Size", int(width * 0.1)},
+			{"Last Record", int(width * 0.22)},
+		})
+	} else {
+		m.table.SetColumns([]table.Column{
+			{"Name", int(float64(ktx.WindowWidth-9) * 0.7)},
+			{"Partitions", int(float64(ktx.WindowWidth-9) * 0.1)},
+			{"Replicas", int(float64(ktx.WindowWidth-9) * 0.1)},
+			{"In Sync Replicas", int(float64(ktx.WindowWidth-9) * 0.1)},
+		})
+	}
 	m.table.SetRows(m.rows)
 
 	if m.moveCursor != nil {
@@ -75,17 +96,40 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			return ui.PublishMsg(nav.LoadCreateTopicPageMsg{})
 		case "ctrl+o":
 			return ui.PublishMsg(nav.LoadTopicConfigPageMsg{})
+		case "f3":
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadTopicDetailsPageMsg{Topic: topic}
+			})
+		case "f9":
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadTopicCGroupsPageMsg{Topic: topic}
+			})
 		case "ctrl+p":
-			return ui.PublishMsg(nav.LoadPublishPageMsg{Topic: m.SelectedTopic()})
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadPublishPageMsg{Topic: topic}
+			})
+		case "f4":
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadImportPageMsg{Topic: topic}
+			})
+		case "f6":
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadCopyFormPageMsg{Topic: topic}
+			})
 		case "f5":
 			m.topics = nil
 			return m.lister.ListTopics
+		case "f8":
+			return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+				return nav.LoadCreatePartitionsPageMsg{Topic: topic}
+			})
+		case "f7":
+			cmds = append(cmds, m.toggleStats())
 		case "enter":
 			// only accept enter when the table is focussed
 			if !m.cmdBar.IsFocussed() {
-				// TODO ignore enter when there are no topics loaded
-				return ui.PublishMsg(nav.LoadConsumptionFormPageMsg{
-					Topic: m.SelectedTopic(),
+				return m.navigateWithSelectedTopic(func(topic *kadmin.Topic) tea.Msg {
+					return nav.LoadConsumptionFormPageMsg{Topic: topic}
 				})
 			}
 		}
@@ -101,6 +145,35 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		cmds = append(cmds, msg.AwaitCompletion)
 	case kadmin.TopicListedMsg:
 		m.topics = msg.Topics
+		if m.showStats {
+			cmds = append(cmds, m.listStats())
+		}
+	case kadmin.TopicStatsListingStartedMsg:
+		if msg.ListingId != m.statsListingId {
+			return nil
+		}
+		m.statsListing = &msg
+		cmds = append(cmds, msg.AwaitNext)
+	case kadmin.TopicStatsListingEndedMsg:
+		if msg.ListingId != m.statsListingId {
+			return nil
+		}
+	case kadmin.TopicStatsListingErrorMsg:
+		if msg.ListingId != m.statsListingId {
+			return nil
+		}
+	case kadmin.TopicStatsListedMsg:
+		if msg.ListingId != m.statsListingId {
+			return nil
+		}
+		for i := range m.topics {
+			if stats, ok := msg.Stats[m.topics[i].Name]; ok {
+				m.topics[i].Stats = &stats
+			}
+		}
+		if m.statsListing != nil {
+			cmds = append(cmds, m.statsListing.AwaitNext)
+		}
 	case kadmin.TopicDeletedMsg:
 		m.topics = slices.DeleteFunc(
 			m.topics,
@@ -131,29 +204,11 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 func (m *Model) filterTopicsBySearchTerm() []table.Row {
 	var rows []table.Row
 	for _, topic := range m.topics {
-		if m.cmdBar.GetSearchTerm() != "" {
-			if strings.Contains(strings.ToLower(topic.Name), strings.ToLower(m.cmdBar.GetSearchTerm())) {
-				rows = append(
-					rows,
-					table.Row{
-						topic.Name,
-						strconv.Itoa(topic.Partitions),
-						strconv.Itoa(topic.Replicas),
-						"N/A",
-					},
-				)
-			}
-		} else {
-			rows = append(
-				rows,
-				table.Row{
-					topic.Name,
-					strconv.Itoa(topic.Partitions),
-					strconv.Itoa(topic.Replicas),
-					"N/A",
-				},
-			)
+		if m.cmdBar.GetSearchTerm() != "" &&
+			!strings.Contains(strings.ToLower(topic.Name), strings.ToLower(m.cmdBar.GetSearchTerm())) {
+			continue
 		}
+		rows = append(rows, m.topicRow(topic))
 	}
 	sort.SliceStable(rows, func(i, j int) bool {
 		return rows[i][0] < rows[j][0]
@@ -161,6 +216,108 @@ func (m *Model) filterTopicsBySearchTerm() []table.Row {
 	return rows
 }
 
+func (m *Model) topicRow(topic kadmin.Topic) table.Row {
+	row := table.Row{
+		topic.Name,
+		strconv.Itoa(topic.Partitions),
+		strconv.Itoa(topic.Replicas),
+		inSyncReplicas(topic),
+	}
+	if !m.showStats {
+		return row
+	}
+	if topic.Stats == nil {
+		return append(row, "…", "…", "…")
+	}
+	return append(
+		row,
+		strconv.FormatInt(topic.Stats.MessageCount, 10),
+		formatSize(topic.Stats.Size),
+		formatTimestamp(topic.Stats.LastTimestamp),
+	)
+}
+
+// inSyncReplicas renders the in-sync replicas out of all replicas of the topic.
+func inSyncReplicas(topic kadmin.Topic) string {
+	return fmt.Sprintf("%d/%d", topic.Isr, topic.Partitions*topic.Replicas)
+}
+
+func formatSize(size int64) string {
+	if size < 0 {
+		return "N/A"
+	}
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func formatTimestamp(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return "-"
+	}
+	return timestamp.Format("2006-01-02 15:04")
+}
+
+// toggleStats shows or hides the stats columns, the stats are only listed while shown.
+func (m *Model) toggleStats() tea.Cmd {
+	m.showStats = !m.showStats
+	if m.showStats {
+		return m.listStats()
+	}
+	m.stopListingStats()
+	return nil
+}
+
+// listStats lists the stats of all topics in the order they are shown, so visible topics are loaded first.
+func (m *Model) listStats() tea.Cmd {
+	m.stopListingStats()
+	var names []string
+	for _, topic := range m.topics {
+		names = append(names, topic.Name)
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelStats = cancel
+	listingId := m.statsListingId
+	return func() tea.Msg {
+		msg := m.statsLister.ListTopicStats(ctx, names)
+		if started, ok := msg.(kadmin.TopicStatsListingStartedMsg); ok {
+			started.ListingId = listingId
+			return started
+		}
+		return msg
+	}
+}
+
+// stopListingStats cancels the current stats listing, after which its messages are ignored.
+func (m *Model) stopListingStats() {
+	if m.cancelStats != nil {
+		m.cancelStats()
+		m.cancelStats = nil
+	}
+	m.statsListing = nil
+	m.statsListingId++
+}
+
+// navigateWithSelectedTopic publishes the navigation message of the selected topic,
+// nothing happens when no topic is selected, e.g. when the search matches none.
+func (m *Model) navigateWithSelectedTopic(navMsg func(topic *kadmin.Topic) tea.Msg) tea.Cmd {
+	topic := m.SelectedTopic()
+	if topic == nil {
+		return nil
+	}
+	return ui.PublishMsg(navMsg(topic))
+}
+
+// SelectedTopic returns the selected topic, nil when none is selected.
 func (m *Model) SelectedTopic() *kadmin.Topic {
 	selectedTopic := m.SelectedTopicName()
 	for _, t := range m.topics {
@@ -168,7 +325,7 @@ func (m *Model) SelectedTopic() *kadmin.Topic {
 			return &t
 		}
 	}
-	panic("selected topic not found")
+	return nil
 }
 
 func (m *Model) SelectedTopicName() string {
@@ -185,7 +342,10 @@ func (m *Model) Title() string {
 }
 
 func (m *Model) Shortcuts() []statusbar.Shortcut {
-	return m.shortcuts
+	if m.showStats {
+		return append(m.shortcuts, statusbar.Shortcut{"Hide Stats", "F7"})
+	}
+	return append(m.shortcuts, statusbar.Shortcut{"Show Stats", "F7"})
 }
 
 func (m *Model) Refresh() tea.Cmd {
@@ -193,15 +353,19 @@ func (m *Model) Refresh() tea.Cmd {
 	return m.lister.ListTopics
 }
 
-func New(topicDeleter kadmin.TopicDeleter, lister kadmin.TopicLister) (*Model, tea.Cmd) {
+func New(
+	topicDeleter kadmin.TopicDeleter,
+	lister kadmin.TopicLister,
+	statsLister kadmin.TopicStatsLister,
+) (*Model, tea.Cmd) {
 	var m = Model{}
 	m.shortcuts = []statusbar.Shortcut{
 		{"Search", "/"},
-		{"Consume", "enter"},
-		{"Publish", "C-p"},
-		{"Create", "C-n"},
-		{"Delete", "F2"},
-		{"Configs", "C-o"},
+		{"Consume/Publish", "enter/C-p"},
+		{"Details/Consumers", "F3/F9"},
+		{"Import/Copy", "F4/F6"},
+		{"Create/Delete", "C-n/F2"},
+		{"Configs/Partitions", "C-o/F8"},
 		{"Refresh", "F5"},
 	}
 	m.table = table.New(
@@ -297,11 +461,45 @@ func New(topicDeleter kadmin.TopicDeleter, lister kadmin.TopicLister) (*Model, t
 		},
 	)
 
+	cmdbar.WithMsgHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.TopicStatsListingStartedMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			cmd := m.SpinWithLoadingMsg("Loading Topic Stats")
+			return true, cmd
+		},
+	)
+
+	cmdbar.WithMsgHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.TopicStatsListingEndedMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.Idle()
+			return true, nil
+		},
+	)
+
+	cmdbar.WithMsgHandler(
+		notifierCmdBar,
+		func(
+			msg kadmin.TopicStatsListingErrorMsg,
+			m *notifier.Model,
+		) (bool, tea.Cmd) {
+			m.ShowErrorMsg("Error loading Topic Stats", msg.Err)
+			return true, nil
+		},
+	)
+
 	m.cmdBar = cmdbar.NewTableCmdsBar[string](
 		cmdbar.NewDeleteCmdBar(deleteMsgFunc, deleteFunc, nil),
 		cmdbar.NewSearchCmdBar("Search topics by name"),
 		notifierCmdBar,
 	)
 	m.lister = lister
+	m.statsLister = statsLister
 	return &m, lister.ListTopics
 }
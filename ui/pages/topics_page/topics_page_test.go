@@ -1,11 +1,18 @@
 package topics_page
 
 import (
+	"context"
+	"fmt"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"ktea/kadmin"
+	"ktea/tests"
 	"ktea/tests/keys"
+	"ktea/ui"
+	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/nav"
 	"testing"
+	"time"
 )
 
 type MockTopicLister struct {
@@ -24,9 +31,39 @@ func (m *MockTopicDeleter) DeleteTopic(_ string) tea.Msg {
 	return nil
 }
 
+type MockTopicStatsLister struct {
+}
+
+type ListTopicStatsCalledMsg struct {
+	Topics []string
+}
+
+func (m *MockTopicStatsLister) ListTopicStats(_ context.Context, topics []string) tea.Msg {
+	return ListTopicStatsCalledMsg{topics}
+}
+
+// StartedTopicStatsLister starts a listing of which the stats are sent by the test.
+type StartedTopicStatsLister struct{}
+
+func (m *StartedTopicStatsLister) ListTopicStats(_ context.Context, _ []string) tea.Msg {
+	return kadmin.TopicStatsListingStartedMsg{}
+}
+
+// startListingStats toggles the stats and feeds the started listing back to the page.
+func startListingStats(page *Model) kadmin.TopicStatsListingStartedMsg {
+	var started kadmin.TopicStatsListingStartedMsg
+	for _, msg := range tests.ExecuteBatchCmd(page.Update(keys.Key(tea.KeyF7))) {
+		if msg, ok := msg.(kadmin.TopicStatsListingStartedMsg); ok {
+			started = msg
+			page.Update(msg)
+		}
+	}
+	return started
+}
+
 func TestTopicsPage(t *testing.T) {
 	t.Run("Ignore KeyMsg when topics aren't loaded yet", func(t *testing.T) {
-		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{})
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
 
 		cmd := page.Update(keys.Key(tea.KeyCtrlN))
 		assert.Nil(t, cmd)
@@ -39,7 +76,7 @@ func TestTopicsPage(t *testing.T) {
 	})
 
 	t.Run("F5 refreshes topic list", func(t *testing.T) {
-		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{})
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
 
 		_ = page.Update(kadmin.TopicListedMsg{
 			Topics: []kadmin.Topic{
@@ -56,4 +93,184 @@ func TestTopicsPage(t *testing.T) {
 
 		assert.IsType(t, ListTopicsCalledMsg{}, cmd())
 	})
+	t.Run("F3 loads the topic details page", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		topic := kadmin.Topic{
+			Name:       "topic1",
+			Partitions: 1,
+			Replicas:   1,
+			Isr:        1,
+		}
+		_ = page.Update(kadmin.TopicListedMsg{Topics: []kadmin.Topic{topic}})
+		page.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		cmd := page.Update(keys.Key(tea.KeyF3))
+
+		assert.Equal(t, nav.LoadTopicDetailsPageMsg{Topic: &topic}, cmd())
+	})
+
+	t.Run("F9 loads the consumer groups of the topic", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		topic := kadmin.Topic{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}
+		_ = page.Update(kadmin.TopicListedMsg{Topics: []kadmin.Topic{topic}})
+		page.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		cmd := page.Update(keys.Key(tea.KeyF9))
+
+		assert.Equal(t, nav.LoadTopicCGroupsPageMsg{Topic: &topic}, cmd())
+	})
+
+	t.Run("F8 loads the create partitions page", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		topic := kadmin.Topic{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}
+		_ = page.Update(kadmin.TopicListedMsg{Topics: []kadmin.Topic{topic}})
+		page.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		cmd := page.Update(keys.Key(tea.KeyF8))
+
+		assert.Equal(t, nav.LoadCreatePartitionsPageMsg{Topic: &topic}, cmd())
+	})
+
+	t.Run("Ignore navigation when the search matches no topic", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}},
+		})
+		page.Update(keys.Key('/'))
+		keys.UpdateKeys(page, "unknown")
+		page.Update(keys.Key(tea.KeyEnter))
+		page.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		for _, key := range []tea.KeyType{
+			tea.KeyF3, tea.KeyF4, tea.KeyF6, tea.KeyF8, tea.KeyF9, tea.KeyCtrlP, tea.KeyEnter,
+		} {
+			assert.NotPanics(t, func() {
+				cmd := page.Update(keys.Key(key))
+				assert.Nil(t, cmd)
+			})
+		}
+	})
+
+	t.Run("Show in-sync replicas out of all replicas", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{
+				{
+					Name:       "topic1",
+					Partitions: 3,
+					Replicas:   2,
+					Isr:        5,
+				},
+			},
+		})
+
+		render := page.View(ui.NewTestKontext(), ui.TestRenderer)
+
+		assert.Contains(t, render, "5/6")
+		assert.NotContains(t, render, "N/A")
+	})
+	t.Run("F7 shows stats and lists them", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{
+				{Name: "topic2", Partitions: 1, Replicas: 1, Isr: 1},
+				{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1},
+			},
+		})
+
+		cmd := page.Update(keys.Key(tea.KeyF7))
+
+		assert.Contains(t, tests.ExecuteBatchCmd(cmd), ListTopicStatsCalledMsg{[]string{"topic1", "topic2"}})
+		assert.Contains(t, page.Shortcuts(), statusbar.Shortcut{"Hide Stats", "F7"})
+		render := page.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "Messages")
+		assert.Contains(t, render, "Last Record")
+	})
+
+	t.Run("Show listed stats", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &StartedTopicStatsLister{})
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{
+				{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1},
+				{Name: "topic2", Partitions: 1, Replicas: 1, Isr: 1},
+				{Name: "topic3", Partitions: 1, Replicas: 1, Isr: 1},
+			},
+		})
+		started := startListingStats(page)
+
+		page.Update(kadmin.TopicStatsListedMsg{
+			ListingId: started.ListingId,
+			Stats: map[string]kadmin.TopicStats{
+				"topic1": {
+					MessageCount:  1234,
+					Size:          5 * 1024 * 1024,
+					LastTimestamp: time.Date(2024, 3, 1, 12, 30, 0, 0, time.Local),
+				},
+				"topic2": {MessageCount: 0, Size: -1},
+			},
+		})
+
+		render := page.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.Contains(t, render, "1234")
+		assert.Contains(t, render, "5.0 MB")
+		assert.Contains(t, render, "2024-03-01 12:30")
+		assert.Contains(t, render, "N/A")
+		// topic3 is still loading
+		assert.Contains(t, render, "…")
+	})
+
+	t.Run("Ignore stats of an earlier listing", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &StartedTopicStatsLister{})
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}},
+		})
+		earlier := startListingStats(page)
+		// refreshing the topics restarts the listing
+		page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}},
+		})
+
+		cmd := page.Update(kadmin.TopicStatsListedMsg{
+			ListingId: earlier.ListingId,
+			Stats:     map[string]kadmin.TopicStats{"topic1": {MessageCount: 1234}},
+		})
+
+		assert.Nil(t, cmd)
+		render := page.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "1234")
+	})
+
+	t.Run("F7 hides stats again", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+		_ = page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}},
+		})
+		page.Update(keys.Key(tea.KeyF7))
+
+		page.Update(keys.Key(tea.KeyF7))
+
+		assert.Contains(t, page.Shortcuts(), statusbar.Shortcut{"Show Stats", "F7"})
+		render := page.View(ui.NewTestKontext(), ui.TestRenderer)
+		assert.NotContains(t, render, "Messages")
+	})
+
+	t.Run("Stats are not listed when hidden", func(t *testing.T) {
+		page, _ := New(&MockTopicDeleter{}, &MockTopicLister{}, &MockTopicStatsLister{})
+
+		cmd := page.Update(kadmin.TopicListedMsg{
+			Topics: []kadmin.Topic{{Name: "topic1", Partitions: 1, Replicas: 1, Isr: 1}},
+		})
+
+		for _, msg := range tests.ExecuteBatchCmd(cmd) {
+			assert.NotEqual(t, "topics_page.ListTopicStatsCalledMsg", fmt.Sprintf("%T", msg))
+		}
+	})
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "N/A", formatSize(-1))
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "2.0 GB", formatSize(2*1024*1024*1024))
 }
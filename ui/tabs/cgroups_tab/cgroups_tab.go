@@ -7,18 +7,21 @@ import (
 	"ktea/kontext"
 	"ktea/ui"
 	"ktea/ui/components/statusbar"
+	"ktea/ui/pages/cgroup_details_page"
 	"ktea/ui/pages/cgroups_page"
 	"ktea/ui/pages/cgroups_topics_page"
 	"ktea/ui/pages/nav"
+	"ktea/ui/pages/reset_offsets_page"
 )
 
 type Model struct {
-	active        nav.Page
-	statusbar     *statusbar.Model
-	offsetLister  kadmin.OffsetLister
-	cgroupLister  kadmin.CGroupLister
-	cgroupDeleter kadmin.CGroupDeleter
-	cgroupsPage   *cgroups_page.Model
+	active         nav.Page
+	statusbar      *statusbar.Model
+	offsetLister   kadmin.OffsetLister
+	offsetResetter kadmin.OffsetResetter
+	cgroupLister   kadmin.CGroupLister
+	cgroupDeleter  kadmin.CGroupDeleter
+	cgroupsPage    *cgroups_page.Model
 }
 
 func (m *Model) View(ktx *kontext.ProgramKtx, renderer *ui.Renderer) string {
@@ -37,6 +40,14 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		cmds = append(cmds, cmd)
 		m.active = cgroupsTopicsPage
 		return tea.Batch(cmds...)
+	case nav.LoadCGroupDetailsPageMsg:
+		m.active = cgroup_details_page.New(msg.Group)
+		m.statusbar = statusbar.New(m.active)
+		return nil
+	case nav.LoadResetOffsetsPageMsg:
+		m.active = reset_offsets_page.New(m.offsetResetter, msg.GroupName, msg.Topic, msg.Partitions)
+		m.statusbar = statusbar.New(m.active)
+		return nil
 	case nav.LoadCGroupsPageMsg:
 		var cmd tea.Cmd
 		if m.cgroupsPage == nil {
@@ -61,11 +72,13 @@ func New(
 	cgroupLister kadmin.CGroupLister,
 	cgroupDeleter kadmin.CGroupDeleter,
 	consumerGroupOffsetLister kadmin.OffsetLister,
+	offsetResetter kadmin.OffsetResetter,
 ) (*Model, tea.Cmd) {
 	cgroupsPage, cmd := cgroups_page.New(cgroupLister, cgroupDeleter)
 
 	m := &Model{}
 	m.offsetLister = consumerGroupOffsetLister
+	m.offsetResetter = offsetResetter
 	m.cgroupLister = cgroupLister
 	m.cgroupDeleter = cgroupDeleter
 	m.cgroupsPage = cgroupsPage
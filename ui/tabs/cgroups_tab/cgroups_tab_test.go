@@ -7,7 +7,9 @@ import (
 	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
+	"ktea/tests/keys"
 	"ktea/ui"
+	"ktea/ui/pages/nav"
 	"strings"
 	"testing"
 )
@@ -18,6 +20,16 @@ func (m *MockConsumerGroupOffsetLister) ListOffsets(group string) tea.Msg {
 	return nil
 }
 
+type MockOffsetResetter struct{}
+
+func (m *MockOffsetResetter) PreviewOffsetReset(details kadmin.OffsetResetDetails) tea.Msg {
+	return nil
+}
+
+func (m *MockOffsetResetter) ResetOffsets(preview kadmin.OffsetResetPreview) tea.Msg {
+	return nil
+}
+
 type MockConsumerGroupLister struct{}
 
 func (m *MockConsumerGroupLister) ListCGroups() tea.Msg {
@@ -32,7 +44,7 @@ func (m *MockConsumerGroupDeleter) DeleteCGroup(name string) tea.Msg {
 
 func TestGroupsTab(t *testing.T) {
 	t.Run("List consumer groups", func(t *testing.T) {
-		groupsTab, _ := New(&MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{})
+		groupsTab, _ := New(&MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{}, &MockOffsetResetter{})
 
 		groupsTab.Update(kadmin.ConsumerGroupsListedMsg{
 			ConsumerGroups: []*kadmin.ConsumerGroup{
@@ -114,4 +126,82 @@ func TestGroupsTab(t *testing.T) {
 		})
 	})
 
+	t.Run("Show total lag of consumer groups", func(t *testing.T) {
+		groupsTab, _ := New(&MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{}, &MockOffsetResetter{})
+
+		groupsTab.Update(kadmin.ConsumerGroupsListedMsg{
+			ConsumerGroups: []*kadmin.ConsumerGroup{
+				{
+					Name:     "Group1",
+					TotalLag: 4242,
+				},
+				{
+					Name:     "Group2",
+					TotalLag: kadmin.UnknownLag,
+				},
+			},
+		})
+
+		render := ansi.Strip(groupsTab.View(&kontext.ProgramKtx{
+			WindowWidth:     100,
+			WindowHeight:    100,
+			AvailableHeight: 100,
+			Config: &config.Config{
+				Clusters: []config.Cluster{
+					{
+						Name:             "PRD",
+						BootstrapServers: []string{"localhost:9092"},
+					},
+				},
+			},
+		}, ui.TestRenderer))
+
+		assert.Contains(t, render, "Total Lag")
+		assert.Regexp(t, `Group1\s+0\s+4242`, render)
+		assert.Regexp(t, `Group2\s+0\s+-`, render)
+	})
+
+	t.Run("Show state and load details of the selected group", func(t *testing.T) {
+		groupsTab, _ := New(&MockConsumerGroupLister{}, &MockConsumerGroupDeleter{}, &MockConsumerGroupOffsetLister{}, &MockOffsetResetter{})
+		group := &kadmin.ConsumerGroup{
+			Name:        "Group1",
+			State:       "Stable",
+			Coordinator: &kadmin.Broker{ID: 1, Address: "localhost:9092"},
+			Members: []kadmin.GroupMember{
+				{
+					MemberId:   "Group1Id1",
+					ClientHost: "127.0.0.1",
+					Assignment: map[string][]int32{"topic1": {0, 1}},
+				},
+			},
+		}
+		groupsTab.Update(kadmin.ConsumerGroupsListedMsg{
+			ConsumerGroups: []*kadmin.ConsumerGroup{group},
+		})
+		ktx := &kontext.ProgramKtx{
+			WindowWidth:     100,
+			WindowHeight:    100,
+			AvailableHeight: 100,
+			Config: &config.Config{
+				Clusters: []config.Cluster{
+					{
+						Name:             "PRD",
+						BootstrapServers: []string{"localhost:9092"},
+					},
+				},
+			},
+		}
+
+		render := ansi.Strip(groupsTab.View(ktx, ui.TestRenderer))
+		assert.Regexp(t, `Group1\s+Stable\s+1`, render)
+
+		cmd := groupsTab.Update(keys.Key(tea.KeyF3))
+		msg := cmd()
+		assert.Equal(t, nav.LoadCGroupDetailsPageMsg{Group: group}, msg)
+
+		groupsTab.Update(msg)
+		render = ansi.Strip(groupsTab.View(ktx, ui.TestRenderer))
+		assert.Contains(t, render, "coordinator broker 1 (localhost:9092)")
+		assert.Regexp(t, `Group1Id1\s+127.0.0.1\s+topic1\s+0, 1`, render)
+	})
 }
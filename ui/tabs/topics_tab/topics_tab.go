@@ -4,6 +4,7 @@ import (
 	"context"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"ktea/config"
 	"ktea/kadmin"
 	"ktea/kontext"
 	"ktea/ui"
@@ -12,10 +13,16 @@ import (
 	"ktea/ui/pages/configs_page"
 	"ktea/ui/pages/consumption_form_page"
 	"ktea/ui/pages/consumption_page"
+	"ktea/ui/pages/copy_page"
+	"ktea/ui/pages/create_partitions_page"
 	"ktea/ui/pages/create_topic_page"
+	"ktea/ui/pages/import_page"
 	"ktea/ui/pages/nav"
 	"ktea/ui/pages/publish_page"
+	"ktea/ui/pages/reassign_partitions_page"
 	"ktea/ui/pages/record_details_page"
+	"ktea/ui/pages/topic_cgroups_page"
+	"ktea/ui/pages/topic_details_page"
 	"ktea/ui/pages/topics_page"
 )
 
@@ -24,6 +31,7 @@ type Model struct {
 	topicsPage        *topics_page.Model
 	statusbar         *statusbar.Model
 	ka                kadmin.Kadmin
+	kaInstantiator    kadmin.Instantiator
 	ktx               *kontext.ProgramKtx
 	consumptionPage   nav.Page
 	recordDetailsPage nav.Page
@@ -52,6 +60,13 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		//isn't focused anymore.
 		return m.topicsPage.Update(msg)
 
+	case kadmin.TopicStatsListingStartedMsg,
+		kadmin.TopicStatsListedMsg,
+		kadmin.TopicStatsListingErrorMsg,
+		kadmin.TopicStatsListingEndedMsg:
+		// stats keep loading in the background while another page is shown
+		return m.topicsPage.Update(msg)
+
 	case nav.LoadTopicsPageMsg:
 		if msg.Refresh {
 			cmds = append(cmds, m.topicsPage.Refresh())
@@ -77,8 +92,36 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case nav.LoadCreateTopicPageMsg:
 		m.active = create_topic_page.New(m.ka)
 
+	case nav.LoadTopicDetailsPageMsg:
+		page, cmd := topic_details_page.New(m.ka, m.ka, msg.Topic)
+		cmds = append(cmds, cmd)
+		m.active = page
+
+	case nav.LoadTopicCGroupsPageMsg:
+		page, cmd := topic_cgroups_page.New(m.ka, msg.Topic)
+		cmds = append(cmds, cmd)
+		m.active = page
+
+	case nav.LoadReassignPartitionsPageMsg:
+		page, cmd := reassign_partitions_page.New(m.ka, m.ka, m.ka, msg.Topic)
+		cmds = append(cmds, cmd)
+		m.active = page
+
+	case nav.LoadCreatePartitionsPageMsg:
+		m.active = create_partitions_page.New(m.ka, msg.Topic)
+
 	case nav.LoadPublishPageMsg:
-		m.active = publish_page.New(m.ka, msg.Topic)
+		keyFormat, valueFormat := m.topicFormats(msg.Topic.Name)
+		m.active = publish_page.New(m.ka, msg.Topic, keyFormat, valueFormat)
+
+	case nav.LoadCopyFormPageMsg:
+		m.active = consumption_form_page.NewForCopy(msg.Topic, m.ktx)
+
+	case nav.LoadCopyPageMsg:
+		m.active = copy_page.New(m.ka, m.ka, m.kaInstantiator, msg.ReadDetails, m.ktx)
+
+	case nav.LoadImportPageMsg:
+		m.active = import_page.New(m.ka, msg.Topic)
 
 	case nav.LoadCachedConsumptionPageMsg:
 		m.active = m.consumptionPage
@@ -101,12 +144,21 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
-func New(ktx *kontext.ProgramKtx, ka kadmin.Kadmin) (*Model, tea.Cmd) {
+// topicFormats returns the key and value format configured for the topic on the active cluster.
+func (m *Model) topicFormats(topic string) (config.Format, config.Format) {
+	if m.ktx.Config == nil || m.ktx.Config.ActiveCluster() == nil {
+		return config.DefaultFormat, config.DefaultFormat
+	}
+	return m.ktx.Config.ActiveCluster().FormatsOf(topic)
+}
+
+func New(ktx *kontext.ProgramKtx, ka kadmin.Kadmin, kaInstantiator kadmin.Instantiator) (*Model, tea.Cmd) {
 	var cmd tea.Cmd
-	listTopicView, cmd := topics_page.New(ka, ka)
+	listTopicView, cmd := topics_page.New(ka, ka, ka)
 
 	model := &Model{}
 	model.ka = ka
+	model.kaInstantiator = kaInstantiator
 	model.ktx = ktx
 	model.active = listTopicView
 	model.topicsPage = listTopicView